@@ -5,11 +5,17 @@ import (
 	"os"
 
 	"github.com/javaquery/unosdk/internal/cli"
+	"github.com/javaquery/unosdk/internal/shim"
 	"github.com/javaquery/unosdk/pkg/utils"
 	"github.com/javaquery/unosdk/pkg/version"
 )
 
 func main() {
+	// Started through a shim: run the tool of the active SDK
+	if name := shim.Name(os.Args[0]); name != "" {
+		os.Exit(cli.RunShim(name, os.Args[1:]))
+	}
+
 	// Initialize logger
 	logger := utils.NewLogger()
 	defer logger.Sync()
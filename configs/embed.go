@@ -0,0 +1,9 @@
+// Package configs holds configuration files embedded into the unosdk binary
+package configs
+
+import _ "embed"
+
+// SDKs is the built-in declarative provider file (sdks.yaml)
+//
+//go:embed sdks.yaml
+var SDKs []byte
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var cacheOlderThan string
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage downloaded SDK archives",
+	Long: `Manage the SDK archives kept in ~/.unosdk/cache/downloads.
+
+Install, update and env install download archives into the cache. An
+interrupted download is resumed by the next install, and an archive that
+matched its vendor checksum is reused when the same version is installed
+again, e.g. after an uninstall.`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the cached downloads",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheList,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove all cached downloads",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClean,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cached downloads that were not used recently",
+	Long: `Remove the cached downloads, partial ones included, that no install used
+within the given age.
+
+Examples:
+  # Remove downloads not used in the last 30 days
+  unosdk cache prune
+
+  # Remove downloads not used in the last week
+  unosdk cache prune --older-than 7d`,
+	Args: cobra.NoArgs,
+	RunE: runCachePrune,
+}
+
+func init() {
+	cachePruneCmd.Flags().StringVar(&cacheOlderThan, "older-than", "30d", "Age of the downloads to remove, e.g. 7d or 12h")
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+func runCacheList(cmd *cobra.Command, args []string) error {
+	cache := installer.NewDefaultDownloadCache()
+	entries, err := cache.List()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No cached downloads.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tSIZE\tSTATUS\tLAST USED\tURL")
+	fmt.Fprintln(w, "----\t----\t------\t---------\t---")
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.File, formatBytes(entry.Size), cacheStatus(entry),
+			entry.LastUsed.Local().Format("2006-01-02 15:04"), entry.URL)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d download(s), %s in %s\n", len(entries), formatBytes(total), cache.Dir())
+	return nil
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	removed, err := installer.NewDefaultDownloadCache().Clean()
+	printRemovedDownloads(cmd.OutOrStdout(), removed)
+	return err
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	age, err := parseAge(cacheOlderThan)
+	if err != nil {
+		return err
+	}
+
+	removed, err := installer.NewDefaultDownloadCache().Prune(time.Now().Add(-age))
+	printRemovedDownloads(cmd.OutOrStdout(), removed)
+	return err
+}
+
+// printRemovedDownloads prints how many downloads were removed and the space
+// that was freed
+func printRemovedDownloads(w io.Writer, removed []*installer.CachedDownload) {
+	var freed int64
+	for _, entry := range removed {
+		freed += entry.Size
+	}
+	fmt.Fprintf(w, "✓ Removed %d download(s), freed %s\n", len(removed), formatBytes(freed))
+}
+
+// cacheStatus describes the state of a cached download
+func cacheStatus(entry *installer.CachedDownload) string {
+	switch {
+	case !entry.Complete:
+		return "partial"
+	case entry.Verified:
+		return "verified"
+	default:
+		return "unverified"
+	}
+}
+
+// parseAge parses a duration that may also be given in days, e.g. 30d
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age: %s", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age: %s", value)
+	}
+	return age, nil
+}
+
+// formatBytes formats a size in bytes with a binary unit
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/installer"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"0d", 0, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"d", 0, true},
+		{"-1d", 0, true},
+		{"week", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAge(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{800 * 1024 * 1024, "800.0 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.size); got != tt.want {
+			t.Errorf("formatBytes(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestCacheCommands(t *testing.T) {
+	newTestEnv(t)
+
+	cache := installer.NewDefaultDownloadCache()
+	download := cache.Lookup("https://example.com/dist/flutter.tar.xz", "")
+	if err := cache.Save(download); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	os.WriteFile(download.Path(), make([]byte, 2048), 0644)
+
+	var out bytes.Buffer
+	cacheListCmd.SetOut(&out)
+	if err := runCacheList(cacheListCmd, nil); err != nil {
+		t.Fatalf("cache list error = %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "flutter.tar.xz  2.0 KiB  partial") {
+		t.Errorf("cache list = %q, want the partial download", got)
+	}
+
+	// Recently used downloads are kept
+	out.Reset()
+	cachePruneCmd.SetOut(&out)
+	cacheOlderThan = "1d"
+	t.Cleanup(func() { cacheOlderThan = "30d" })
+	if err := runCachePrune(cachePruneCmd, nil); err != nil {
+		t.Fatalf("cache prune error = %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "Removed 0 download(s)") {
+		t.Errorf("cache prune = %q, want nothing removed", got)
+	}
+
+	out.Reset()
+	cacheCleanCmd.SetOut(&out)
+	if err := runCacheClean(cacheCleanCmd, nil); err != nil {
+		t.Fatalf("cache clean error = %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "Removed 1 download(s), freed 2.0 KiB") {
+		t.Errorf("cache clean = %q, want the download removed", got)
+	}
+	if entries, _ := cache.List(); len(entries) != 0 {
+		t.Errorf("cache clean left %d downloads", len(entries))
+	}
+}
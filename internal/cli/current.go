@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var currentCmd = &cobra.Command{
+	Use:   "current [sdk-type]",
+	Short: "Show the active SDK of each type",
+	Long: `Show the default SDK of each installed type, as recorded by install and
+switch, and check it against what a new terminal actually runs: the first
+match of the SDK's main tool (java, node, mvn, ...) on the effective PATH.
+
+A ⚠ marks a type whose tool resolves to another installation, e.g. a Java
+from the System PATH that comes before the User PATH on Windows.
+
+Examples:
+  # Show all SDK types
+  unosdk current
+
+  # Show Java only
+  unosdk current java`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCurrent,
+}
+
+func runCurrent(cmd *cobra.Command, args []string) error {
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	types := installedTypes(reg)
+	if len(args) == 1 {
+		sdkType := models.SDKType(args[0])
+		if len(reg.ListByType(sdkType)) == 0 {
+			return fmt.Errorf("no %s SDK installed", sdkType)
+		}
+		types = []models.SDKType{sdkType}
+	}
+	if len(types) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No SDKs installed.")
+		return nil
+	}
+
+	dirs := system.EffectivePath(newEnvBackend())
+	for _, sdkType := range types {
+		printCurrent(cmd.OutOrStdout(), reg, sdkType, dirs)
+	}
+	return nil
+}
+
+// printCurrent prints the default SDK of a type and where its main tool
+// resolves on PATH
+func printCurrent(w io.Writer, reg *registry.Registry, sdkType models.SDKType, dirs []string) {
+	def, hasDefault := reg.Default(sdkType)
+	tools := shim.Tools(sdkType)
+
+	var path string
+	var found bool
+	if len(tools) > 0 {
+		path, found = shim.FindExecutable(dirs, tools[0])
+	}
+
+	var owner *models.SDK
+	var description string
+	if found {
+		owner, description = executableOwner(reg, tools[0], path)
+	}
+
+	switch {
+	case !hasDefault:
+		fmt.Fprintf(w, "⚠ %s: no default recorded, select one with 'unosdk switch'\n", sdkType)
+	case owner != nil && owner.InstallPath == def.InstallPath:
+		fmt.Fprintf(w, "✓ %s: %s %s\n", sdkType, def.Provider, def.Version)
+	default:
+		fmt.Fprintf(w, "⚠ %s: %s %s is the default but is not active\n", sdkType, def.Provider, def.Version)
+	}
+
+	switch {
+	case len(tools) == 0:
+	case !found:
+		fmt.Fprintf(w, "    %s is not on PATH\n", tools[0])
+	default:
+		fmt.Fprintf(w, "    %s → %s (%s)\n", tools[0], path, description)
+	}
+}
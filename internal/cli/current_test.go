@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestRunCurrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		onPath   []string
+		want     string
+		wantTool string
+	}{
+		{name: "default active", onPath: []string{"21"}, want: "✓ java: openjdk 21", wantTool: "21"},
+		{name: "default shadowed", onPath: []string{"17", "21"}, want: "⚠ java: openjdk 21 is the default but is not active", wantTool: "17"},
+		{name: "not on PATH", want: "java is not on PATH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			t.Setenv("PATH", "")
+			sdks := map[string]*models.SDK{
+				"17": addTestSDK(t, models.JavaSDK, "openjdk", "17"),
+				"21": addTestSDK(t, models.JavaSDK, "openjdk", "21"),
+			}
+			tools := make(map[string]string)
+			for version, sdk := range sdks {
+				tools[version] = addTestExecutable(t, sdk, "java")
+			}
+
+			reg, err := registry.NewRegistry()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := reg.SetDefault(sdks["21"]); err != nil {
+				t.Fatal(err)
+			}
+			for i := len(tt.onPath) - 1; i >= 0; i-- {
+				if err := env.PrependPath(system.UserScope, sdkPathDirs(sdks[tt.onPath[i]])[0]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+			currentCmd.SetOut(&out)
+			t.Cleanup(func() { currentCmd.SetOut(nil) })
+
+			if err := runCurrent(currentCmd, []string{"java"}); err != nil {
+				t.Fatalf("runCurrent() error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+			if tt.wantTool != "" && !strings.Contains(out.String(), "java → "+tools[tt.wantTool]) {
+				t.Errorf("output = %q, want java resolved to %s", out.String(), tools[tt.wantTool])
+			}
+		})
+	}
+}
+
+func TestRunCurrent_NotInstalled(t *testing.T) {
+	newTestEnv(t)
+
+	if err := runCurrent(currentCmd, []string{"java"}); err == nil {
+		t.Error("runCurrent() should fail for a type without installations")
+	}
+}
@@ -0,0 +1,487 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/installer"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// Severities of doctor findings
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// windowsPathLimit is the longest PATH the Windows environment variables
+// dialog and many tools handle; longer values are cut off
+const windowsPathLimit = 2047
+
+var (
+	doctorFix  bool
+	doctorJSON bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the SDK setup",
+	Long: `Check the installed SDKs and the environment for problems:
+
+  missing-install   registry entries whose install directory no longer exists
+  orphaned-install  SDK directories under ~/.unosdk missing from the registry,
+                    except the staging directories of running installs
+  duplicate-path    unosdk entries listed more than once in the User PATH
+  stale-path        unosdk entries in the User PATH that no longer exist or
+                    belong to an SDK that is not the default
+  shadowed-sdk      foreign installations earlier on PATH than the default SDK
+  java-home         JAVA_HOME pointing outside the default Java
+  path-length       a PATH close to the Windows limit of 2047 characters
+
+Each finding has a severity (error or warning). --fix repairs the
+findings that can be repaired; deleting orphaned SDK directories asks for
+confirmation first, removing foreign entries from the System PATH
+needs administrator rights and asks for confirmation first, and Windows
+system directories such as System32 are never removed. doctor exits with an error while any error
+remains, so scripts can gate on it, and --json prints a machine-readable
+report.
+
+Examples:
+  # Check the setup
+  unosdk doctor
+
+  # Repair what can be repaired
+  unosdk doctor --fix
+
+  # Report for provisioning scripts
+  unosdk doctor --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair the findings that can be repaired")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the report as JSON")
+}
+
+// finding is a problem reported by doctor
+type finding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable"`
+	Fixed    bool   `json:"fixed"`
+	FixError string `json:"fix_error,omitempty"`
+
+	// fix repairs the problem, nil when it cannot be repaired automatically
+	fix func() error
+}
+
+// doctorReport is the result of a doctor run
+type doctorReport struct {
+	OK       bool       `json:"ok"`
+	Errors   int        `json:"errors"`
+	Warnings int        `json:"warnings"`
+	Findings []*finding `json:"findings"`
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	env := newEnvBackend()
+
+	var findings []*finding
+	findings = append(findings, checkInstalls(reg, cfg.ConfigDir)...)
+	findings = append(findings, checkUserPath(reg, env, cfg.ConfigDir)...)
+	findings = append(findings, checkShadowedSDKs(reg, env)...)
+	findings = append(findings, checkJavaHome(reg, env)...)
+	if runtime.GOOS == "windows" {
+		findings = append(findings, checkPathLength(len(strings.Join(system.EffectivePath(env), ";")))...)
+	}
+
+	report := &doctorReport{Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []*finding{}
+	}
+	for _, f := range findings {
+		if doctorFix && f.fix != nil {
+			if err := f.fix(); err != nil {
+				f.FixError = err.Error()
+			} else {
+				f.Fixed = true
+			}
+		}
+		if f.Fixed {
+			continue
+		}
+		switch f.Severity {
+		case severityError:
+			report.Errors++
+		case severityWarning:
+			report.Warnings++
+		}
+	}
+	report.OK = report.Errors == 0
+
+	w := cmd.OutOrStdout()
+	if doctorJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	} else {
+		printDoctorReport(w, report)
+	}
+
+	if !report.OK {
+		return fmt.Errorf("doctor found %d error(s)", report.Errors)
+	}
+	return nil
+}
+
+// printDoctorReport prints the findings for humans
+func printDoctorReport(w io.Writer, report *doctorReport) {
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(w, "✓ No problems found")
+		return
+	}
+
+	fixable := 0
+	for _, f := range report.Findings {
+		icon := "⚠"
+		switch {
+		case f.Fixed:
+			icon = "✓"
+		case f.Severity == severityError:
+			icon = "❌"
+		}
+		fmt.Fprintf(w, "%s [%s] %s: %s\n", icon, f.Severity, f.Check, f.Message)
+
+		switch {
+		case f.Fixed:
+			fmt.Fprintln(w, "    fixed")
+		case f.FixError != "":
+			fmt.Fprintf(w, "    fix failed: %s\n", f.FixError)
+		case f.Fixable:
+			fixable++
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", report.Errors, report.Warnings)
+	if fixable > 0 {
+		fmt.Fprintf(w, "Run 'unosdk doctor --fix' to repair %d of them.\n", fixable)
+	}
+}
+
+// checkInstalls compares the registry with the SDK directories on disk:
+// <configDir>/<type>/<provider>/<version>
+func checkInstalls(reg *registry.Registry, configDir string) []*finding {
+	var findings []*finding
+
+	for _, sdk := range reg.List() {
+		if _, err := os.Stat(sdk.InstallPath); !os.IsNotExist(err) {
+			continue
+		}
+		findings = append(findings, &finding{
+			Check:    "missing-install",
+			Severity: severityError,
+			Message:  fmt.Sprintf("%s %s %s is registered but %s does not exist (fix: remove it from the registry and the environment)", sdk.Type, sdk.Provider, sdk.Version, sdk.InstallPath),
+			Fixable:  true,
+			fix:      func() error { return forgetSDK(reg, sdk) },
+		})
+	}
+
+	for _, sdkType := range providerCatalog().Types() {
+		providerDirs, _ := os.ReadDir(filepath.Join(configDir, string(sdkType)))
+		for _, providerDir := range providerDirs {
+			if !providerDir.IsDir() {
+				continue
+			}
+			versionDirs, _ := os.ReadDir(filepath.Join(configDir, string(sdkType), providerDir.Name()))
+			for _, versionDir := range versionDirs {
+				dir := filepath.Join(configDir, string(sdkType), providerDir.Name(), versionDir.Name())
+				// A recent staging directory belongs to an install that is
+				// still running, possibly in another process
+				if !versionDir.IsDir() || isRegisteredDir(reg, dir) || installer.IsActiveStaging(dir) {
+					continue
+				}
+				findings = append(findings, &finding{
+					Check:    "orphaned-install",
+					Severity: severityWarning,
+					Message:  fmt.Sprintf("%s is not in the registry, e.g. left over from an interrupted install (fix: delete it after confirmation)", dir),
+					Fixable:  true,
+					fix: func() error {
+						if !confirm(fmt.Sprintf("Delete %s?", dir)) {
+							return fmt.Errorf("not confirmed, %s was kept", dir)
+						}
+						return os.RemoveAll(dir)
+					},
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// isRegisteredDir reports whether a directory holds, or lies within, the
+// installation of a registered SDK
+func isRegisteredDir(reg *registry.Registry, dir string) bool {
+	for _, sdk := range reg.List() {
+		if isWithinDir(dir, sdk.InstallPath) || isWithinDir(sdk.InstallPath, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// forgetSDK removes an SDK whose files are gone from the registry, the
+// environment and the shims
+func forgetSDK(reg *registry.Registry, sdk *models.SDK) error {
+	if err := reg.Remove(sdk.Type, sdk.Provider, sdk.Version); err != nil {
+		return fmt.Errorf("failed to remove from registry: %w", err)
+	}
+	if _, err := newEnvEngine().Remove(sdkEnvSpec(sdk), sdk.InstallPath); err != nil {
+		return fmt.Errorf("failed to cleanup environment variables: %w", err)
+	}
+	if shim.Enabled() {
+		return removeUnusedShims(reg)
+	}
+	return nil
+}
+
+// checkUserPath reports unosdk entries of the User PATH that are listed twice,
+// no longer exist or belong to an SDK that is not the default
+func checkUserPath(reg *registry.Registry, env system.EnvBackend, configDir string) []*finding {
+	value, err := env.Get(system.UserScope, "Path")
+	if err != nil {
+		return nil
+	}
+	shimsDir, _ := shim.Dir()
+
+	var findings []*finding
+	var seen []string
+	for _, entry := range filepath.SplitList(value) {
+		if entry == "" || !isWithinDir(configDir, entry) {
+			continue
+		}
+
+		if system.ContainsPath(seen, entry) {
+			findings = append(findings, &finding{
+				Check:    "duplicate-path",
+				Severity: severityWarning,
+				Message:  fmt.Sprintf("%s is listed more than once in the User PATH (fix: keep the first entry)", entry),
+				Fixable:  true,
+				fix:      func() error { return dedupeUserPath(env) },
+			})
+			continue
+		}
+		seen = append(seen, entry)
+
+		reason := ""
+		sdk := sdkContaining(reg, entry)
+		if _, err := os.Stat(entry); os.IsNotExist(err) {
+			reason = "does not exist"
+		} else if sdk != nil && shim.Enabled() {
+			reason = fmt.Sprintf("belongs to %s %s %s, shims mode keeps SDK directories off PATH", sdk.Type, sdk.Provider, sdk.Version)
+		} else if sdk != nil {
+			if def, ok := reg.Default(sdk.Type); ok && def.InstallPath != sdk.InstallPath {
+				reason = fmt.Sprintf("belongs to %s %s %s, which is not the default", sdk.Type, sdk.Provider, sdk.Version)
+			}
+		} else if entry != shimsDir && isSDKTypeDir(configDir, entry) {
+			reason = "belongs to no installed SDK"
+		}
+		if reason == "" {
+			continue
+		}
+
+		findings = append(findings, &finding{
+			Check:    "stale-path",
+			Severity: severityWarning,
+			Message:  fmt.Sprintf("%s %s (fix: remove it from the User PATH)", entry, reason),
+			Fixable:  true,
+			fix:      func() error { return env.RemovePath(system.UserScope, entry) },
+		})
+	}
+	return findings
+}
+
+// isSDKTypeDir reports whether path lies in the directory of an SDK type
+// below the unosdk directory, e.g. ~/.unosdk/java/...
+func isSDKTypeDir(configDir, path string) bool {
+	rel, err := filepath.Rel(configDir, path)
+	if err != nil {
+		return false
+	}
+	first := strings.Split(rel, string(filepath.Separator))[0]
+	return providerCatalog().HasType(models.SDKType(first))
+}
+
+// dedupeUserPath removes repeated entries from the User PATH, keeping the
+// first occurrence of each
+func dedupeUserPath(env system.EnvBackend) error {
+	value, err := env.Get(system.UserScope, "Path")
+	if err != nil {
+		return err
+	}
+
+	var kept []string
+	for _, entry := range filepath.SplitList(value) {
+		if entry != "" && !system.ContainsPath(kept, entry) {
+			kept = append(kept, entry)
+		}
+	}
+	deduped := strings.Join(kept, string(os.PathListSeparator))
+	if deduped == value {
+		return nil
+	}
+	return env.Set(system.UserScope, "Path", deduped)
+}
+
+// checkShadowedSDKs reports foreign installations that come before the SDKs
+// on PATH: those of the System PATH on Windows, those earlier on the PATH of
+// the current process elsewhere
+func checkShadowedSDKs(reg *registry.Registry, env system.EnvBackend) []*finding {
+	var before string
+	if runtime.GOOS == "windows" {
+		systemPath, err := env.Get(system.SystemScope, "Path")
+		if err != nil {
+			return nil
+		}
+		before = systemPath
+	} else {
+		before = pathBeforeManaged(os.Getenv("PATH"))
+	}
+
+	var findings []*finding
+	for _, sdkType := range installedTypes(reg) {
+		name, displayName := conflictNames(sdkType)
+		if name == "" {
+			continue
+		}
+		conflicts := system.DetectSDKConflicts(before, name, isManagedPath)
+		if len(conflicts) == 0 {
+			continue
+		}
+
+		f := &finding{
+			Check:    "shadowed-sdk",
+			Severity: severityError,
+			Message:  fmt.Sprintf("%s installation(s) earlier on PATH take precedence over unosdk: %s", displayName, strings.Join(conflicts, ", ")),
+		}
+		if runtime.GOOS != "windows" {
+			f.Message += " (remove them from your shell profile)"
+			findings = append(findings, f)
+			continue
+		}
+
+		// Windows system directories are never removed from PATH
+		removable, protected := splitSystemRootPaths(conflicts)
+		if len(protected) > 0 {
+			f.Message += fmt.Sprintf(" (remove the %s executables from %s by hand)", displayName, strings.Join(protected, ", "))
+		}
+		switch {
+		case len(removable) == 0:
+		case env.IsAdmin():
+			f.Message += " (fix: remove them from the System PATH after confirmation)"
+			f.Fixable = true
+			f.fix = func() error {
+				if !confirm(fmt.Sprintf("Remove %s from the System PATH?", strings.Join(removable, ", "))) {
+					return fmt.Errorf("not confirmed, the System PATH was left unchanged")
+				}
+				return removeSystemPathEntries(env, removable)
+			}
+		default:
+			f.Message += " (run 'unosdk doctor --fix' as Administrator to remove them from the System PATH)"
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// pathBeforeManaged returns the entries of a PATH value that come before the
+// first unosdk-managed entry
+func pathBeforeManaged(value string) string {
+	var before []string
+	for _, entry := range filepath.SplitList(value) {
+		if entry != "" && isManagedPath(entry) {
+			break
+		}
+		before = append(before, entry)
+	}
+	return strings.Join(before, string(os.PathListSeparator))
+}
+
+// checkJavaHome reports a User JAVA_HOME that is not the home of the default
+// Java
+func checkJavaHome(reg *registry.Registry, env system.EnvBackend) []*finding {
+	java, ok := reg.Default(models.JavaSDK)
+	if !ok {
+		return nil
+	}
+	vars, _ := sdkEnvSpec(java).Expand(java.InstallPath)
+	want, ok := vars["JAVA_HOME"]
+	if !ok {
+		return nil
+	}
+
+	got, err := env.Get(system.UserScope, "JAVA_HOME")
+	fix := func() error { return env.Set(system.UserScope, "JAVA_HOME", want) }
+	switch {
+	case err != nil && !shim.Enabled():
+		return []*finding{{
+			Check:    "java-home",
+			Severity: severityWarning,
+			Message:  fmt.Sprintf("JAVA_HOME is not set, the default Java is %s (fix: set it)", want),
+			Fixable:  true,
+			fix:      fix,
+		}}
+	case err == nil && !isWithinDir(java.InstallPath, got):
+		return []*finding{{
+			Check:    "java-home",
+			Severity: severityError,
+			Message:  fmt.Sprintf("JAVA_HOME is %s but the default Java is %s %s at %s (fix: point it at the default)", got, java.Provider, java.Version, want),
+			Fixable:  true,
+			fix:      fix,
+		}}
+	}
+	return nil
+}
+
+// checkPathLength reports a Windows PATH of the given length that is close to
+// or over windowsPathLimit
+func checkPathLength(length int) []*finding {
+	switch {
+	case length > windowsPathLimit:
+		return []*finding{{
+			Check:    "path-length",
+			Severity: severityError,
+			Message:  fmt.Sprintf("PATH is %d characters long, over the limit of %d; entries at the end are ignored by some tools", length, windowsPathLimit),
+		}}
+	case length > windowsPathLimit*9/10:
+		return []*finding{{
+			Check:    "path-length",
+			Severity: severityWarning,
+			Message:  fmt.Sprintf("PATH is %d characters long, close to the limit of %d", length, windowsPathLimit),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// runTestDoctor runs doctor with the given flags and returns the JSON report
+func runTestDoctor(t *testing.T, fix bool) (*doctorReport, error) {
+	t.Helper()
+	doctorFix, doctorJSON = fix, true
+	t.Cleanup(func() { doctorFix, doctorJSON = false, false })
+
+	var out bytes.Buffer
+	doctorCmd.SetOut(&out)
+	t.Cleanup(func() { doctorCmd.SetOut(nil) })
+
+	err := runDoctor(doctorCmd, nil)
+	var report doctorReport
+	if jsonErr := json.Unmarshal(out.Bytes(), &report); jsonErr != nil {
+		t.Fatalf("invalid JSON report %q: %v", out.String(), jsonErr)
+	}
+	return &report, err
+}
+
+// setTestDefault records an SDK as the default and applies its environment
+func setTestDefault(t *testing.T, sdk *models.SDK) {
+	t.Helper()
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.SetDefault(sdk); err != nil {
+		t.Fatal(err)
+	}
+	if err := setupSDKEnvironment(sdk, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunDoctor_NoProblems(t *testing.T) {
+	newTestEnv(t)
+	t.Setenv("PATH", "")
+	setTestDefault(t, addTestSDK(t, models.JavaSDK, "openjdk", "21"))
+
+	report, err := runTestDoctor(t, false)
+	if err != nil {
+		t.Fatalf("runDoctor() error = %v", err)
+	}
+	if !report.OK || len(report.Findings) != 0 {
+		t.Errorf("report = %+v, want no findings", report)
+	}
+}
+
+func TestRunDoctor_Fix(t *testing.T) {
+	env := newTestEnv(t)
+	t.Setenv("PATH", "")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	node := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+	setTestDefault(t, java21)
+
+	// node's files are gone, 11 was never registered
+	if err := os.RemoveAll(node.InstallPath); err != nil {
+		t.Fatal(err)
+	}
+	home, _ := os.UserHomeDir()
+	orphan := filepath.Join(home, ".unosdk", "java", "openjdk", "11")
+	if err := os.MkdirAll(orphan, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	java21Bin := sdkPathDirs(java21)[0]
+	userPath := strings.Join([]string{java21Bin, sdkPathDirs(java17)[0], java21Bin}, string(os.PathListSeparator))
+	if err := env.Set(system.UserScope, "Path", userPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Set(system.UserScope, "JAVA_HOME", java17.InstallPath); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := runTestDoctor(t, false)
+	if err == nil {
+		t.Error("runDoctor() should fail while errors remain")
+	}
+	var checks []string
+	for _, f := range report.Findings {
+		checks = append(checks, f.Check)
+	}
+	sort.Strings(checks)
+	want := []string{"duplicate-path", "java-home", "missing-install", "orphaned-install", "stale-path"}
+	if !reflect.DeepEqual(checks, want) {
+		t.Errorf("checks = %v, want %v", checks, want)
+	}
+
+	answerConfirm(t, true)
+	report, err = runTestDoctor(t, true)
+	if err != nil {
+		t.Fatalf("runDoctor() --fix error = %v", err)
+	}
+	for _, f := range report.Findings {
+		if !f.Fixed {
+			t.Errorf("finding %s was not fixed: %s", f.Check, f.FixError)
+		}
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reg.Get(models.NodeSDK, "nodejs", "20.10.0"); ok {
+		t.Error("the missing node install is still registered")
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Error("the orphaned install directory still exists")
+	}
+	if got, want := env.PathEntries(system.UserScope), []string{java21Bin}; !reflect.DeepEqual(got, want) {
+		t.Errorf("User PATH = %v, want %v", got, want)
+	}
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); !isWithinDir(java21.InstallPath, got) {
+		t.Errorf("JAVA_HOME = %v, want it within %v", got, java21.InstallPath)
+	}
+
+	if report, err = runTestDoctor(t, false); err != nil || len(report.Findings) != 0 {
+		t.Errorf("after --fix: findings = %v, error = %v", report.Findings, err)
+	}
+}
+
+func TestCheckInstalls_Orphaned(t *testing.T) {
+	tests := []struct {
+		name        string
+		dir         string
+		age         time.Duration
+		confirmed   bool
+		wantFinding bool
+		wantKept    bool
+	}{
+		{name: "deleted after confirmation", dir: "11", confirmed: true, wantFinding: true, wantKept: false},
+		{name: "kept when declined", dir: "11", confirmed: false, wantFinding: true, wantKept: true},
+		{name: "staging dir of a running install", dir: ".11.staging-123", age: time.Minute, wantFinding: false, wantKept: true},
+		{name: "staging dir of an interrupted install", dir: ".11.staging-123", age: 2 * time.Hour, confirmed: true, wantFinding: true, wantKept: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestEnv(t)
+			addTestSDK(t, models.JavaSDK, "openjdk", "21")
+			cfg, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			orphan := filepath.Join(cfg.ConfigDir, "java", "openjdk", tt.dir)
+			if err := os.MkdirAll(orphan, 0755); err != nil {
+				t.Fatal(err)
+			}
+			modTime := time.Now().Add(-tt.age)
+			if err := os.Chtimes(orphan, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+
+			reg, err := registry.NewRegistry()
+			if err != nil {
+				t.Fatal(err)
+			}
+			findings := checkInstalls(reg, cfg.ConfigDir)
+			if got := len(findings) == 1; got != tt.wantFinding {
+				t.Fatalf("checkInstalls() = %v, want a finding: %v", findings, tt.wantFinding)
+			}
+			if tt.wantFinding {
+				questions := answerConfirm(t, tt.confirmed)
+				if err := findings[0].fix(); (err != nil) != !tt.confirmed {
+					t.Errorf("fix() error = %v, confirmed %v", err, tt.confirmed)
+				}
+				if len(*questions) != 1 {
+					t.Errorf("asked %v, want one confirmation", *questions)
+				}
+			}
+			if _, err := os.Stat(orphan); (err == nil) != tt.wantKept {
+				t.Errorf("%s exists: %v, want %v", orphan, err == nil, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestCheckShadowedSDKs(t *testing.T) {
+	env := newTestEnv(t)
+	java := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	addTestSDK(t, models.GoSDK, "golang", "1.23.5")
+	foreign := filepath.Join(t.TempDir(), "jdk-17", "bin")
+	addForeignExecutable(t, foreign, "java")
+	// A bin directory named like an SDK that provides none of its tools
+	google := filepath.Join(t.TempDir(), "Google", "bin")
+	addForeignExecutable(t, google, "chrome")
+	systemPath := strings.Join([]string{google, foreign}, string(os.PathListSeparator))
+
+	if runtime.GOOS == "windows" {
+		env.Admin = true
+		if err := env.Set(system.SystemScope, "Path", systemPath); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		t.Setenv("PATH", strings.Join([]string{systemPath, sdkPathDirs(java)[0]}, string(os.PathListSeparator)))
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings := checkShadowedSDKs(reg, env)
+	if len(findings) != 1 || !strings.Contains(findings[0].Message, foreign) {
+		t.Fatalf("checkShadowedSDKs() = %v, want one finding for %s", findings, foreign)
+	}
+	if findings[0].Severity != severityError {
+		t.Errorf("severity = %v, want %v", findings[0].Severity, severityError)
+	}
+
+	if runtime.GOOS == "windows" {
+		// --fix leaves the System PATH alone unless the user confirms
+		answerConfirm(t, false)
+		if err := findings[0].fix(); err == nil {
+			t.Error("fix() should fail when the removal is declined")
+		}
+		if got, _ := env.Get(system.SystemScope, "Path"); got != systemPath {
+			t.Errorf("System PATH = %v, want it unchanged (%v)", got, systemPath)
+		}
+	}
+}
+
+func TestCheckPathLength(t *testing.T) {
+	tests := []struct {
+		length int
+		want   string
+	}{
+		{length: 500},
+		{length: 1900, want: severityWarning},
+		{length: 2100, want: severityError},
+	}
+
+	for _, tt := range tests {
+		findings := checkPathLength(tt.length)
+		got := ""
+		if len(findings) > 0 {
+			got = findings[0].Severity
+		}
+		if got != tt.want {
+			t.Errorf("checkPathLength(%d) severity = %q, want %q", tt.length, got, tt.want)
+		}
+	}
+}
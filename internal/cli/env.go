@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/installer"
+	"github.com/javaquery/unosdk/internal/project"
+	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var envShell string
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Activate the SDK versions of the current project",
+	Long: `Activate the SDK versions pinned by the project in the current shell.
+
+The project file, .unosdkrc or unosdk.yaml, is looked up in the current
+directory and its parents. It maps SDK types to a provider and a version:
+
+  java: openjdk 21
+  node: nodejs lts
+  maven: apache 3.9.9
+
+The version files of other tools are read as well: .tool-versions (asdf),
+.sdkmanrc (SDKMAN), .nvmrc, .python-version, .java-version and the toolchain
+directive of go.mod. For each SDK type the nearest file wins. Versions from
+files naming no provider use the default_providers of ~/.unosdk/config.yaml,
+otherwise openjdk, nodejs, python, apache, gradle, golang and flutter.
+
+Like use, env prints shell code that the wrapper from 'unosdk init' evaluates.
+In shims mode the shims pick up the project versions on their own.
+
+Examples:
+  # Install the missing SDKs of the project
+  unosdk env install
+
+  # Use the project's SDK versions in this terminal
+  unosdk env
+
+  # Show and revert the changes unosdk made to environment variables
+  unosdk env history
+  unosdk env undo`,
+	Args: cobra.NoArgs,
+	RunE: runEnv,
+}
+
+var envInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the SDK versions of the current project",
+	Long:  `Install every SDK version the project file pins that is not installed yet.`,
+	Args:  cobra.NoArgs,
+	RunE:  runEnvInstall,
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envShell, "shell", "", "Shell to print code for (bash, zsh, fish, powershell, cmd), detected by default")
+	envCmd.AddCommand(envInstallCmd)
+	envCmd.AddCommand(envHistoryCmd)
+	envCmd.AddCommand(envUndoCmd)
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	sh, err := resolveShell(envShell)
+	if err != nil {
+		return err
+	}
+
+	file, err := findProjectFile()
+	if err != nil {
+		return err
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	ctx := context.Background()
+	vars := make(map[string]string)
+	path := os.Getenv("PATH")
+	var entries []string
+	var sdks []*models.SDK
+	for _, pin := range file.Pins {
+		sdk, err := resolvePin(ctx, reg, pin)
+		if err != nil {
+			return err
+		}
+		sdks = append(sdks, sdk)
+
+		sdkVars, sdkPath := sessionEnv(reg, sdk, path)
+		for name, value := range sdkVars {
+			vars[name] = value
+		}
+		entries = sdkPath
+		path = strings.Join(sdkPath, string(os.PathListSeparator))
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), sh.Render(vars, entries))
+
+	// Messages go to stderr, stdout is evaluated by the wrapper
+	for i, sdk := range sdks {
+		fmt.Fprintf(cmd.ErrOrStderr(), "✓ Using %s %s %s (%s) in this shell\n", sdk.Type, sdk.Provider, sdk.Version, file.Pins[i].Source)
+	}
+	if isTerminal(os.Stdout) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠ The code above was only printed. Add %s to %s to apply it automatically.\n",
+			sh.InitLine(), sh.Profile())
+	}
+
+	return nil
+}
+
+func runEnvInstall(cmd *cobra.Command, args []string) error {
+	file, err := findProjectFile()
+	if err != nil {
+		return err
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	fmt.Println("Installing the SDKs of the project...")
+
+	ctx, stop := interruptContext()
+	defer stop()
+	inst := installer.NewInstaller(providerCatalog())
+	installed := 0
+	for _, pin := range file.Pins {
+		if _, err := providerCatalog().Lookup(pin.Type, pin.Provider); err != nil {
+			return fmt.Errorf("invalid version in %s: %w", pin.Source, err)
+		}
+		if sdk, err := resolvePin(ctx, reg, pin); err == nil {
+			fmt.Printf("✓ %s %s %s already installed\n", sdk.Type, sdk.Provider, sdk.Version)
+			continue
+		}
+
+		fmt.Printf("Installing %s...\n", pin)
+		sdk, err := inst.Install(ctx, pin.Type, pin.Provider, pin.Version, runtime.GOARCH)
+		if err != nil {
+			return fmt.Errorf("installation of %s failed: %w", pin, err)
+		}
+		if err := registerInstall(inst, reg, sdk); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Successfully installed %s %s %s\n", sdk.Type, sdk.Provider, sdk.Version)
+		installed++
+
+		if shim.Enabled() {
+			if err := ensureDefault(reg, sdk.Type); err != nil {
+				fmt.Printf("⚠ Warning: Failed to record default SDK: %v\n", err)
+			}
+			if err := createShims(sdk.Type); err != nil {
+				fmt.Printf("⚠ Warning: Failed to create shims: %v\n", err)
+			}
+		}
+	}
+
+	fmt.Printf("\n✓ %d SDK(s) installed, the project is ready\n", installed)
+	if shim.Enabled() {
+		fmt.Println("  The shims use the project versions inside the project directory.")
+	} else {
+		fmt.Println("  Run 'unosdk env' to use them in this terminal.")
+	}
+
+	return nil
+}
+
+// findProjectFile finds the project file of the working directory
+func findProjectFile() (*project.File, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	file, err := project.Find(dir, defaultProviders())
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("no version file found in %s or its parents (looked for %s)", dir, strings.Join(project.FileNames, ", "))
+	}
+	return file, nil
+}
+
+// defaultProviders returns the providers configured for version files that
+// name none, from the default_providers of ~/.unosdk/config.yaml
+func defaultProviders() map[models.SDKType]string {
+	cfg, err := config.New()
+	if err != nil {
+		return nil
+	}
+	settings, err := cfg.LoadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: %v\n", err)
+		return nil
+	}
+
+	providers := make(map[models.SDKType]string, len(settings.DefaultProviders))
+	for sdkType, provider := range settings.DefaultProviders {
+		providers[models.SDKType(sdkType)] = provider
+	}
+	return providers
+}
+
+// resolvePin finds the installed SDK satisfying a pin. Aliases like "lts"
+// are resolved through the provider when no installed version matches as is.
+func resolvePin(ctx context.Context, reg *registry.Registry, pin project.Pin) (*models.SDK, error) {
+	// An installed match needs no provider, which keeps the shims from
+	// loading the catalog
+	installed := reg.ListByType(pin.Type)
+	if sdk, ok := project.Match(installed, pin); ok {
+		return sdk, nil
+	}
+
+	provider, err := providerCatalog().Lookup(pin.Type, pin.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if version, err := providers.ResolveVersion(ctx, provider, pin.Version); err == nil && version != pin.Version {
+		resolved := pin
+		resolved.Version = version
+		if sdk, ok := project.Match(installed, resolved); ok {
+			return sdk, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s (from %s) is not installed, run 'unosdk env install'", pin, pin.Source)
+}
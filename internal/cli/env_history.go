@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit int
+	undoForce    bool
+)
+
+var envHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the changes unosdk made to environment variables",
+	Long: `Show the environment changes recorded in ~/.unosdk/env-journal.jsonl, grouped
+by the command that made them, newest last. Every change to a User or System
+variable, PATH included, is recorded with its old and new value.
+
+The PATH of both scopes from before unosdk changed anything for the first
+time is kept in ~/.unosdk/path-snapshot.json.`,
+	Args: cobra.NoArgs,
+	RunE: runEnvHistory,
+}
+
+var envUndoCmd = &cobra.Command{
+	Use:   "undo [n]",
+	Short: "Revert the environment changes of the last commands",
+	Long: `Restore the values environment variables had before the last n commands
+that changed them (1 by default), newest first. A variable changed again
+since, by hand or another program, stops the undo unless --force is given.
+Reverting System variables needs administrator rights. Only the environment
+is restored: installed SDKs and the recorded defaults stay as they are.
+
+Examples:
+  # Revert the last switch, install or uninstall
+  unosdk env undo
+
+  # Revert the last three
+  unosdk env undo 3`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEnvUndo,
+}
+
+func init() {
+	envHistoryCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of commands to show, 0 for all")
+	envUndoCmd.Flags().BoolVar(&undoForce, "force", false, "Restore variables that were changed again since")
+}
+
+func runEnvHistory(cmd *cobra.Command, args []string) error {
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	entries, err := system.ReadJournal(cfg.ConfigDir)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	runs := system.GroupRuns(entries)
+	if len(runs) == 0 {
+		fmt.Fprintln(w, "No environment changes recorded.")
+	}
+
+	first := 0
+	if historyLimit > 0 && len(runs) > historyLimit {
+		first = len(runs) - historyLimit
+	}
+	for i := first; i < len(runs); i++ {
+		printJournalRun(w, i+1, runs[i])
+	}
+
+	if snapshot, err := system.ReadPathSnapshot(cfg.ConfigDir); err == nil && snapshot != nil {
+		fmt.Fprintf(w, "\nPATH before the first change (%s): %s\n",
+			snapshot.Time.Local().Format("2006-01-02 15:04"), filepath.Join(cfg.ConfigDir, system.PathSnapshotFile))
+	}
+	return nil
+}
+
+// printJournalRun prints the changes of one command
+func printJournalRun(w io.Writer, number int, run *system.JournalRun) {
+	status := ""
+	switch {
+	case run.Undone:
+		status = "  (undone)"
+	case run.Undoes != "":
+		status = "  (undo)"
+	}
+	fmt.Fprintf(w, "#%d  %s  %s%s\n", number, run.Time.Local().Format("2006-01-02 15:04"), run.Command, status)
+
+	for _, entry := range run.Entries {
+		if !isPathName(entry.Name) {
+			fmt.Fprintf(w, "      %s %s: %s → %s\n", entry.Scope, entry.Name, journalValue(entry.Old), journalValue(entry.New))
+			continue
+		}
+		added, removed := pathChanges(entry.Old, entry.New)
+		for _, dir := range added {
+			fmt.Fprintf(w, "      %s PATH: + %s\n", entry.Scope, dir)
+		}
+		for _, dir := range removed {
+			fmt.Fprintf(w, "      %s PATH: - %s\n", entry.Scope, dir)
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			fmt.Fprintf(w, "      %s PATH: reordered\n", entry.Scope)
+		}
+	}
+}
+
+func runEnvUndo(cmd *cobra.Command, args []string) error {
+	n := 1
+	if len(args) == 1 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			return fmt.Errorf("invalid number of commands: %s", args[0])
+		}
+	}
+
+	env, ok := newEnvBackend().(*system.JournaledEnv)
+	if !ok {
+		return fmt.Errorf("the environment journal is not available")
+	}
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	entries, err := system.ReadJournal(cfg.ConfigDir)
+	if err != nil {
+		return err
+	}
+
+	// Undos are not undone themselves, nor are runs reverted before
+	var pending []*system.JournalRun
+	runs := system.GroupRuns(entries)
+	for i := len(runs) - 1; i >= 0 && len(pending) < n; i-- {
+		if !runs[i].Undone && runs[i].Undoes == "" {
+			pending = append(pending, runs[i])
+		}
+	}
+	if len(pending) == 0 {
+		return fmt.Errorf("no environment changes to undo")
+	}
+
+	w := cmd.OutOrStdout()
+	for _, run := range pending {
+		if err := env.Undo(run, undoForce); err != nil {
+			return fmt.Errorf("failed to undo '%s': %w (see 'unosdk env history')", run.Command, err)
+		}
+		fmt.Fprintf(w, "✓ Reverted '%s'\n", run.Command)
+	}
+	fmt.Fprintln(w, reloadHint())
+	return nil
+}
+
+// journalValue formats a recorded value
+func journalValue(value *string) string {
+	if value == nil {
+		return "(unset)"
+	}
+	return *value
+}
+
+// pathChanges returns the directories added to and removed from a PATH value
+func pathChanges(from, to *string) ([]string, []string) {
+	var before, after []string
+	if from != nil {
+		before = filepath.SplitList(*from)
+	}
+	if to != nil {
+		after = filepath.SplitList(*to)
+	}
+
+	var added, removed []string
+	for _, dir := range after {
+		if dir != "" && !system.ContainsPath(before, dir) {
+			added = append(added, dir)
+		}
+	}
+	for _, dir := range before {
+		if dir != "" && !system.ContainsPath(after, dir) {
+			removed = append(removed, dir)
+		}
+	}
+	return added, removed
+}
+
+// isPathName reports whether a variable name refers to PATH
+func isPathName(name string) bool {
+	return strings.EqualFold(name, "PATH")
+}
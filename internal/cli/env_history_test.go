@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// newTestRun attributes the following environment changes to a new command
+func newTestRun(t *testing.T) {
+	t.Helper()
+	previous := journalRun
+	journalRun = system.NewRunID()
+	t.Cleanup(func() { journalRun = previous })
+}
+
+func TestRunEnvUndo(t *testing.T) {
+	env := newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	newTestRun(t)
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "17"}); err != nil {
+		t.Fatal(err)
+	}
+	newTestRun(t)
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	envHistoryCmd.SetOut(&out)
+	envUndoCmd.SetOut(&bytes.Buffer{})
+	t.Cleanup(func() { envHistoryCmd.SetOut(nil); envUndoCmd.SetOut(nil) })
+
+	if err := runEnvHistory(envHistoryCmd, nil); err != nil {
+		t.Fatalf("runEnvHistory() error = %v", err)
+	}
+	for _, want := range []string{
+		"#1 ", "#2 ",
+		"User JAVA_HOME: (unset) → " + java17.InstallPath,
+		"User JAVA_HOME: " + java17.InstallPath + " → " + java21.InstallPath,
+		"User PATH: + " + sdkPathDirs(java21)[0],
+		"User PATH: - " + sdkPathDirs(java17)[0],
+		"PATH before the first change",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("history = %q, want %q", out.String(), want)
+		}
+	}
+
+	// Undo the second switch
+	newTestRun(t)
+	if err := runEnvUndo(envUndoCmd, nil); err != nil {
+		t.Fatalf("runEnvUndo() error = %v", err)
+	}
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != java17.InstallPath {
+		t.Errorf("JAVA_HOME = %v, want %v", got, java17.InstallPath)
+	}
+	if got, want := env.PathEntries(system.UserScope), sdkPathDirs(java17); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+
+	// Then the first one; the undo itself is skipped
+	newTestRun(t)
+	if err := runEnvUndo(envUndoCmd, []string{"1"}); err != nil {
+		t.Fatalf("runEnvUndo() error = %v", err)
+	}
+	if _, err := env.Get(system.UserScope, "JAVA_HOME"); err == nil {
+		t.Error("JAVA_HOME should be unset after undoing both switches")
+	}
+	if got := env.PathEntries(system.UserScope); len(got) != 0 {
+		t.Errorf("PATH = %v, want it empty", got)
+	}
+
+	if err := runEnvUndo(envUndoCmd, nil); err == nil {
+		t.Error("runEnvUndo() should fail with nothing left to undo")
+	}
+}
+
+func TestRunEnvUndo_ChangedSince(t *testing.T) {
+	env := newTestEnv(t)
+	addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	newTestRun(t)
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatal(err)
+	}
+	// Changed by hand, bypassing the journal
+	env.Set(system.UserScope, "JAVA_HOME", "/opt/other-jdk")
+
+	if err := runEnvUndo(envUndoCmd, nil); err == nil {
+		t.Error("runEnvUndo() should fail for a variable changed since")
+	}
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != "/opt/other-jdk" {
+		t.Errorf("JAVA_HOME = %v, want it unchanged", got)
+	}
+}
@@ -1,18 +1,98 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/providers"
 	"github.com/javaquery/unosdk/internal/registry"
 	"github.com/javaquery/unosdk/internal/system"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
+// newEnvBackend creates the backend used to persist environment changes,
+// recording them in the environment journal. Tests replace it with an
+// in-memory environment.
+var newEnvBackend = func() system.EnvBackend {
+	return journaled(system.NewEnvBackend())
+}
+
+// confirm asks a yes/no question on the terminal. Anything but an explicit
+// yes, the end of input included, is a no. Tests replace it.
+var confirm = func(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// journalRun identifies the environment changes of this unosdk invocation
+var journalRun = system.NewRunID()
+
+// journaled wraps a backend so that its changes are recorded in the
+// environment journal under ~/.unosdk, attributed to the running command
+func journaled(backend system.EnvBackend) system.EnvBackend {
+	cfg, err := config.New()
+	if err != nil {
+		return backend
+	}
+	command := strings.Join(append([]string{"unosdk"}, os.Args[1:]...), " ")
+	return system.NewJournaledEnv(backend, cfg.ConfigDir, journalRun, command)
+}
+
+// newEnvEngine creates the engine that configures SDK environments. Variables
+// are only replaced or removed while they point at a unosdk-managed path.
+func newEnvEngine() *system.EnvEngine {
+	engine := system.NewEnvEngine(newEnvBackend())
+	engine.Managed = isManagedPath
+	return engine
+}
+
+// isManagedPath reports whether a path lies inside the unosdk directory or an
+// installed SDK
+func isManagedPath(path string) bool {
+	var roots []string
+	if cfg, err := config.New(); err == nil {
+		roots = append(roots, cfg.ConfigDir)
+	}
+	if reg, err := registry.NewRegistry(); err == nil {
+		for _, sdk := range reg.List() {
+			roots = append(roots, sdk.InstallPath)
+		}
+	}
+
+	for _, root := range roots {
+		if isWithinDir(root, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithinDir reports whether path is dir or one of its descendants
+func isWithinDir(dir, path string) bool {
+	if runtime.GOOS == "windows" {
+		dir, path = strings.ToLower(dir), strings.ToLower(path)
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // cleanupExistingSDKPaths removes all existing PATH entries for the same SDK type
 // This includes other unosdk installations and other installations of the same SDK
 func cleanupExistingSDKPaths(reg *registry.Registry, sdk *models.SDK) error {
-	env := system.NewWindowsEnv()
-	isAdmin := env.IsAdmin()
+	engine := newEnvEngine()
 
 	// Get all installed SDKs of the same type
 	installedSDKs := reg.ListByType(sdk.Type)
@@ -23,146 +103,141 @@ func cleanupExistingSDKPaths(reg *registry.Registry, sdk *models.SDK) error {
 			continue
 		}
 
-		// Remove PATH entries based on SDK type
-		switch sdk.Type {
-		case models.JavaSDK:
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
-
-		case models.NodeSDK:
-			_ = env.RemoveFromPath(installedSDK.InstallPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(installedSDK.InstallPath)
-			}
+		// Variables are left alone, they are overwritten when the SDK is set as default
+		pathSpec := models.EnvSpec{Path: sdkEnvSpec(installedSDK).Path}
+		if _, err := engine.Remove(pathSpec, installedSDK.InstallPath); err != nil {
+			return err
+		}
+	}
 
-		case models.PythonSDK:
-			_ = env.RemoveFromPath(installedSDK.InstallPath)
-			scriptsPath := installedSDK.InstallPath + "\\Scripts"
-			_ = env.RemoveFromPath(scriptsPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(installedSDK.InstallPath)
-				_ = env.RemoveFromSystemPathSingle(scriptsPath)
-			}
+	return nil
+}
 
-		case models.MavenSDK:
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
+// checkSystemPathConflicts warns about SDK installations in System PATH and,
+// when running as admin, offers to remove them. Nothing is removed without
+// confirmation, and Windows system directories are never offered.
+func checkSystemPathConflicts(sdk *models.SDK) {
+	env := newEnvBackend()
 
-		case models.FlutterSDK:
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
+	sdkTypeName, displayName := conflictNames(sdk.Type)
+	if sdkTypeName == "" {
+		return
+	}
 
-		case models.GradleSDK:
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
+	systemPath, err := env.Get(system.SystemScope, "Path")
+	if err != nil {
+		return
+	}
+	conflicts := system.DetectSDKConflicts(systemPath, sdkTypeName, isManagedPath)
 
-		case models.GoSDK:
-			// Remove Go bin and go/bin from PATH
-			_ = env.RemoveFromPath(installedSDK.InstallPath + "\\bin")
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(installedSDK.InstallPath + "\\bin")
-			}
+	if len(conflicts) == 0 {
+		return
+	}
 
-		case models.CppSDK:
-			// Remove MinGW bin from PATH
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
+	fmt.Printf("\n⚠ Found %s installation(s) in System PATH that will take precedence:\n", displayName)
+	for _, path := range conflicts {
+		fmt.Printf("  - %s\n", path)
+	}
 
-		case models.CSDK:
-			// Remove MinGW bin from PATH
-			binPath := installedSDK.InstallPath + "\\bin"
-			_ = env.RemoveFromPath(binPath)
-			if isAdmin {
-				_ = env.RemoveFromSystemPathSingle(binPath)
-			}
+	removable, protected := splitSystemRootPaths(conflicts)
+	if len(protected) > 0 {
+		fmt.Println("\n⚠ unosdk never removes Windows system directories from PATH:")
+		for _, path := range protected {
+			fmt.Printf("  - %s\n", path)
 		}
+		fmt.Printf("  Remove the %s executables there by hand if they are not needed\n", displayName)
+	}
+	if len(removable) == 0 {
+		return
 	}
 
-	return nil
+	if !env.IsAdmin() {
+		fmt.Println("\n⚠ Not running with administrator privileges")
+		fmt.Printf("  To remove them, run the command as Administrator\n")
+		fmt.Println("  Or follow these manual steps:")
+		showManualInstructions(displayName)
+		return
+	}
+
+	if !confirm(fmt.Sprintf("Remove %s from the System PATH?", strings.Join(removable, ", "))) {
+		fmt.Println("  System PATH left unchanged")
+		showManualInstructions(displayName)
+		return
+	}
+
+	if err := removeSystemPathEntries(env, removable); err != nil {
+		fmt.Printf("❌ Failed to remove from System PATH: %v\n", err)
+		showManualInstructions(displayName)
+	} else {
+		fmt.Printf("✓ Successfully removed conflicting %s paths from System PATH\n", displayName)
+		fmt.Printf("  Your unosdk-managed %s will now take precedence\n", displayName)
+	}
 }
 
-// checkSystemPathConflicts detects and removes (if admin) or warns about SDK installations in System PATH
-func checkSystemPathConflicts(sdk *models.SDK) {
-	env := system.NewWindowsEnv()
-	
-	// Map SDK type to search string
-	var sdkTypeName string
-	var displayName string
-	switch sdk.Type {
+// splitSystemRootPaths separates the PATH entries inside the Windows directory
+// (%SystemRoot% and its subdirectories such as System32) from the others.
+// An old java.exe in System32 makes it a conflict, but it must never be
+// removed from PATH.
+func splitSystemRootPaths(entries []string) (removable, protected []string) {
+	for _, entry := range entries {
+		if system.IsSystemRootPath(entry) {
+			protected = append(protected, entry)
+		} else {
+			removable = append(removable, entry)
+		}
+	}
+	return removable, protected
+}
+
+// conflictNames returns the name DetectSDKConflicts knows an SDK type by and
+// its display name, or empty strings for types it cannot detect
+func conflictNames(sdkType models.SDKType) (string, string) {
+	switch sdkType {
 	case models.JavaSDK:
-		sdkTypeName = "java"
-		displayName = "Java"
+		return "java", "Java"
 	case models.NodeSDK:
-		sdkTypeName = "node"
-		displayName = "Node.js"
+		return "node", "Node.js"
 	case models.PythonSDK:
-		sdkTypeName = "python"
-		displayName = "Python"
+		return "python", "Python"
 	case models.MavenSDK:
-		sdkTypeName = "maven"
-		displayName = "Maven"
+		return "maven", "Maven"
 	case models.FlutterSDK:
-		sdkTypeName = "flutter"
-		displayName = "Flutter"
+		return "flutter", "Flutter"
 	case models.GradleSDK:
-		sdkTypeName = "gradle"
-		displayName = "Gradle"
+		return "gradle", "Gradle"
 	case models.GoSDK:
-		sdkTypeName = "go"
-		displayName = "Go"
+		return "go", "Go"
 	case models.CppSDK:
-		sdkTypeName = "mingw"
-		displayName = "C++ (MinGW)"
+		return "mingw", "C++ (MinGW)"
 	case models.CSDK:
-		sdkTypeName = "mingw"
-		displayName = "C (MinGW)"
-	default:
-		return
-	}
-	
-	conflicts := env.DetectSDKConflicts(sdkTypeName)
-	
-	if len(conflicts) == 0 {
-		return
+		return "mingw", "C (MinGW)"
 	}
+	return "", ""
+}
 
-	fmt.Printf("\n⚠ Found %s installation(s) in System PATH that will take precedence:\n", displayName)
-	for _, path := range conflicts {
-		fmt.Printf("  - %s\n", path)
+// removeSystemPathEntries removes directories from the System PATH (requires admin privileges)
+func removeSystemPathEntries(env system.EnvBackend, dirs []string) error {
+	for _, dir := range dirs {
+		if err := env.RemovePath(system.SystemScope, dir); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Check if running with admin privileges
-	if env.IsAdmin() {
-		fmt.Println("\n⚡ Running with administrator privileges - automatically removing conflicts...")
-		
-		if err := env.RemoveFromSystemPath(conflicts); err != nil {
-			fmt.Printf("❌ Failed to remove from System PATH: %v\n", err)
-			showManualInstructions(displayName)
-		} else {
-			fmt.Printf("✓ Successfully removed conflicting %s paths from System PATH\n", displayName)
-			fmt.Printf("  Your unosdk-managed %s will now take precedence\n", displayName)
+// isDefaultSDK reports whether the SDK is the default for its type: the
+// default recorded in the registry or, for types without one (registries
+// written before defaults were recorded), the SDK whose variables and PATH
+// entries are all in the User environment
+func isDefaultSDK(sdk *models.SDK) bool {
+	if reg, err := registry.NewRegistry(); err == nil {
+		if current, ok := reg.Default(sdk.Type); ok {
+			return current.InstallPath == sdk.InstallPath
 		}
-	} else {
-		fmt.Println("\n⚠ Not running with administrator privileges")
-		fmt.Printf("  To automatically fix this, run the command as Administrator\n")
-		fmt.Println("  Or follow these manual steps:")
-		showManualInstructions(displayName)
 	}
+
+	engine := newEnvEngine()
+	return len(engine.Diff(sdkEnvSpec(sdk), sdk.InstallPath)) == 0
 }
 
 func showManualInstructions(sdkName string) {
@@ -170,213 +245,61 @@ func showManualInstructions(sdkName string) {
 	fmt.Println("  1. Press Win+R, type 'sysdm.cpl' and press Enter")
 	fmt.Println("  2. Go to 'Advanced' tab → 'Environment Variables'")
 	fmt.Println("  3. Under 'System variables', select 'Path' → 'Edit'")
-	fmt.Println("  4. Remove the Java-related entries listed above")
+	fmt.Printf("  4. Remove the %s entries listed above\n", sdkName)
 	fmt.Println("  5. Click 'OK' on all dialogs and restart your terminal")
 }
 
 // setupSDKEnvironment configures environment variables for the target SDK
-// If setJavaHome is true, JAVA_HOME will be set for Java SDKs
-func setupSDKEnvironment(sdk *models.SDK, setJavaHome bool) error {
-	env := system.NewWindowsEnv()
-	isAdmin := env.IsAdmin()
-
-	switch sdk.Type {
-	case models.JavaSDK:
-		// Set JAVA_HOME if requested
-		if setJavaHome {
-			// Set in User environment
-			if err := env.SetJavaHome(sdk.InstallPath); err != nil {
-				return fmt.Errorf("failed to set User JAVA_HOME: %w", err)
-			}
-			fmt.Println("  Set User JAVA_HOME=" + sdk.InstallPath)
-			
-			// Also set in System environment if running as admin
-			if isAdmin {
-				if err := env.SetSystemJavaHome(sdk.InstallPath); err != nil {
-					fmt.Printf("  ⚠ Failed to set System JAVA_HOME: %v\n", err)
-				} else {
-					fmt.Println("  Set System JAVA_HOME=" + sdk.InstallPath)
-				}
-			}
-		}
-
-		// Add to PATH
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
-		}
-
-	case models.NodeSDK:
-		// Add to User PATH
-		if err := env.AddToPath(sdk.InstallPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + sdk.InstallPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(sdk.InstallPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + sdk.InstallPath)
-			}
-		}
-
-	case models.PythonSDK:
-		// Add to User PATH
-		if err := env.AddToPath(sdk.InstallPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + sdk.InstallPath)
-		
-		// Add Scripts directory to User PATH
-		scriptsPath := sdk.InstallPath + "\\Scripts"
-		if err := env.AddToPath(scriptsPath); err != nil {
-			return fmt.Errorf("failed to add Scripts to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + scriptsPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(sdk.InstallPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + sdk.InstallPath)
-			}
-			
-			if err := env.AddToSystemPath(scriptsPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add Scripts to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + scriptsPath)
-			}
-		}
+// If setHome is true, the SDK's variables (e.g. JAVA_HOME) are set as well
+func setupSDKEnvironment(sdk *models.SDK, setHome bool) error {
+	engine := newEnvEngine()
 
-	case models.MavenSDK:
-		// Add bin directory to PATH
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
-		}
-
-	case models.FlutterSDK:
-		// Add bin directory to PATH
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
-		}
+	changes, err := engine.Apply(sdkEnvSpec(sdk), sdk.InstallPath, setHome)
+	printEnvChanges(changes)
+	return err
+}
 
-	case models.GradleSDK:
-		// Add bin directory to PATH
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
+// printEnvChanges prints the environment changes made by the engine
+func printEnvChanges(changes []system.Change) {
+	for _, change := range changes {
+		if change.Err != nil {
+			fmt.Printf("  ⚠ Failed: %s (%v)\n", change, change.Err)
+			continue
 		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
+		if change.Kind == system.ChangeKeepVar {
+			fmt.Printf("  ⚠ %s\n", change)
+			continue
 		}
+		fmt.Println("  " + change.String())
+	}
+}
 
-	case models.GoSDK:
-		// Add bin directory to PATH
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
-		}
+// reloadHint tells the user how to pick up environment changes
+func reloadHint() string {
+	if runtime.GOOS == "windows" {
+		return "Please restart your terminal for changes to take effect."
+	}
+	return fmt.Sprintf("Please restart your terminal or run '. %s' for changes to take effect.", system.NewUnixEnv().EnvFile())
+}
 
-	case models.CppSDK:
-		// MinGW bin directory (install path already includes mingw64 from extraction)
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
-			}
-		}
+// sdkEnvSpec returns the env rules of an SDK: those recorded when it was
+// installed, otherwise those of its provider
+func sdkEnvSpec(sdk *models.SDK) models.EnvSpec {
+	if sdk.Env != nil {
+		return *sdk.Env
+	}
+	return providerEnvSpec(sdk.Type, sdk.Provider)
+}
 
-	case models.CSDK:
-		// MinGW bin directory (install path already includes mingw64 from extraction)
-		binPath := sdk.InstallPath + "\\bin"
-		
-		// Add to User PATH
-		if err := env.AddToPath(binPath); err != nil {
-			return fmt.Errorf("failed to add to User PATH: %w", err)
-		}
-		fmt.Println("  Added to User PATH: " + binPath)
-		
-		// Also add to System PATH if running as admin
-		if isAdmin {
-			if err := env.AddToSystemPath(binPath); err != nil {
-				fmt.Printf("  ⚠ Failed to add to System PATH: %v\n", err)
-			} else {
-				fmt.Println("  Added to System PATH: " + binPath)
+// providerEnvSpec returns the env rules declared by a provider, or the
+// defaults of its type
+func providerEnvSpec(sdkType models.SDKType, name string) models.EnvSpec {
+	if provider, ok := providerCatalog().Get(sdkType, name); ok {
+		if envProvider, ok := provider.(providers.EnvProvider); ok {
+			if spec := envProvider.EnvSpec(); !spec.IsEmpty() {
+				return spec
 			}
 		}
 	}
-
-	return nil
+	return models.DefaultEnvSpec(sdkType, runtime.GOOS)
 }
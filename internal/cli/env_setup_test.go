@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// newTestEnv points the home directory at a temporary directory and
+// replaces the environment backend with an in-memory one, journaled like the
+// real one
+func newTestEnv(t *testing.T) *system.MemoryEnv {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("JAVA_HOME", "")
+
+	env := system.NewMemoryEnv()
+	previous := newEnvBackend
+	newEnvBackend = func() system.EnvBackend { return journaled(env) }
+	t.Cleanup(func() { newEnvBackend = previous })
+	return env
+}
+
+// addTestSDK creates an install directory and records it in the registry
+func addTestSDK(t *testing.T, sdkType models.SDKType, provider, version string) *models.SDK {
+	t.Helper()
+	home, _ := os.UserHomeDir()
+	installPath := filepath.Join(home, ".unosdk", string(sdkType), provider, version)
+	if err := os.MkdirAll(filepath.Join(installPath, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdk := &models.SDK{Type: sdkType, Provider: provider, Version: version, InstallPath: installPath}
+	if err := reg.Add(sdk); err != nil {
+		t.Fatal(err)
+	}
+	return sdk
+}
+
+// addForeignExecutable creates a tool in a directory outside unosdk and
+// returns its path
+func addForeignExecutable(t *testing.T, dir, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("tool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sdkPathDirs returns the PATH directories of an SDK
+func sdkPathDirs(sdk *models.SDK) []string {
+	_, dirs := sdkEnvSpec(sdk).Expand(sdk.InstallPath)
+	return dirs
+}
+
+func TestSetupSDKEnvironment(t *testing.T) {
+	tests := []struct {
+		name       string
+		admin      bool
+		wantSystem bool
+	}{
+		{
+			name:       "user only",
+			admin:      false,
+			wantSystem: false,
+		},
+		{
+			name:       "admin also writes system scope",
+			admin:      true,
+			wantSystem: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.Admin = tt.admin
+			sdk := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+			if err := setupSDKEnvironment(sdk, true); err != nil {
+				t.Fatalf("setupSDKEnvironment() error = %v", err)
+			}
+
+			binPath := filepath.Join(sdk.InstallPath, "bin")
+			if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != sdk.InstallPath {
+				t.Errorf("User JAVA_HOME = %v, want %v", got, sdk.InstallPath)
+			}
+			if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, []string{binPath}) {
+				t.Errorf("User PATH = %v, want %v", got, []string{binPath})
+			}
+
+			_, err := env.Get(system.SystemScope, "JAVA_HOME")
+			if gotSystem := err == nil; gotSystem != tt.wantSystem {
+				t.Errorf("System JAVA_HOME set = %v, want %v", gotSystem, tt.wantSystem)
+			}
+		})
+	}
+}
+
+func TestSetupSDKEnvironment_HomeVariables(t *testing.T) {
+	env := newTestEnv(t)
+	foreign := filepath.Join(string(filepath.Separator)+"opt", "maven")
+	env.Set(system.UserScope, "M2_HOME", foreign)
+	maven := addTestSDK(t, models.MavenSDK, "apache", "3.9.9")
+
+	if err := setupSDKEnvironment(maven, true); err != nil {
+		t.Fatalf("setupSDKEnvironment() error = %v", err)
+	}
+
+	// MAVEN_HOME is written, the M2_HOME of a foreign install is kept
+	if got, _ := env.Get(system.UserScope, "MAVEN_HOME"); got != maven.InstallPath {
+		t.Errorf("MAVEN_HOME = %v, want %v", got, maven.InstallPath)
+	}
+	if got, _ := env.Get(system.UserScope, "M2_HOME"); got != foreign {
+		t.Errorf("M2_HOME = %v, want %v", got, foreign)
+	}
+
+	if _, err := cleanupEnvironment(maven); err != nil {
+		t.Fatalf("cleanupEnvironment() error = %v", err)
+	}
+	if _, err := env.Get(system.UserScope, "MAVEN_HOME"); err == nil {
+		t.Error("MAVEN_HOME should be removed")
+	}
+	if got, _ := env.Get(system.UserScope, "M2_HOME"); got != foreign {
+		t.Errorf("M2_HOME = %v, want %v", got, foreign)
+	}
+}
+
+func TestIsManagedPath(t *testing.T) {
+	newTestEnv(t)
+	home, _ := os.UserHomeDir()
+	custom := filepath.Join(t.TempDir(), "jdk")
+	reg, _ := registry.NewRegistry()
+	reg.Add(&models.SDK{Type: models.JavaSDK, Provider: "openjdk", Version: "21", InstallPath: custom})
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "unosdk directory", path: filepath.Join(home, ".unosdk", "go", "golang", "1.23.5"), want: true},
+		{name: "custom install path", path: filepath.Join(custom, "bin", "java"), want: true},
+		{name: "sibling of the unosdk directory", path: filepath.Join(home, ".unosdk-old"), want: false},
+		{name: "foreign install", path: filepath.Join(home, "tools", "maven"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isManagedPath(tt.path); got != tt.want {
+				t.Errorf("isManagedPath(%v) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDefaultSDK(t *testing.T) {
+	env := newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	node := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+
+	env.Set(system.UserScope, "JAVA_HOME", java21.InstallPath)
+	env.PrependPath(system.UserScope, filepath.Join(java21.InstallPath, "bin"))
+	env.Set(system.UserScope, "NODE_HOME", node.InstallPath)
+	env.PrependPath(system.UserScope, sdkPathDirs(node)[0])
+
+	// java17 is on PATH, but JAVA_HOME points elsewhere
+	env.PrependPath(system.UserScope, filepath.Join(java17.InstallPath, "bin"))
+
+	tests := []struct {
+		name string
+		sdk  *models.SDK
+		want bool
+	}{
+		{name: "java matching JAVA_HOME", sdk: java21, want: true},
+		{name: "other java", sdk: java17, want: false},
+		{name: "node in PATH", sdk: node, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDefaultSDK(tt.sdk); got != tt.want {
+				t.Errorf("isDefaultSDK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// answerConfirm makes the confirmation prompts answer with confirmed
+func answerConfirm(t *testing.T, confirmed bool) *[]string {
+	t.Helper()
+	var questions []string
+	previous := confirm
+	confirm = func(question string) bool {
+		questions = append(questions, question)
+		return confirmed
+	}
+	t.Cleanup(func() { confirm = previous })
+	return &questions
+}
+
+func TestCheckSystemPathConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		admin     bool
+		confirmed bool
+		wantAsked bool
+		wantKept  bool
+	}{
+		{name: "confirmed", admin: true, confirmed: true, wantAsked: true, wantKept: false},
+		{name: "declined", admin: true, confirmed: false, wantAsked: true, wantKept: true},
+		{name: "not admin", admin: false, confirmed: true, wantAsked: false, wantKept: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.Admin = true
+			questions := answerConfirm(t, tt.confirmed)
+			sdk := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+			root := t.TempDir()
+			// Named like unosdk, but not one of its installations
+			conflict := filepath.Join(root, "tools", "unosdk-old", "jdk", "bin")
+			addForeignExecutable(t, conflict, "java")
+			// The unosdk installation itself is no conflict
+			managed := sdkPathDirs(sdk)[0]
+			addForeignExecutable(t, managed, "java")
+			// An old java in the Windows directory is reported, never removed
+			windows := filepath.Join(root, "Windows")
+			t.Setenv("SystemRoot", windows)
+			t.Setenv("windir", windows)
+			system32 := filepath.Join(windows, "System32")
+			addForeignExecutable(t, system32, "java")
+			// Named like a Java directory, but provides no java executable
+			other := filepath.Join(root, "javascript", "bin")
+			addForeignExecutable(t, other, "eslint")
+			env.PrependPath(system.SystemScope, managed)
+			env.PrependPath(system.SystemScope, other)
+			env.PrependPath(system.SystemScope, system32)
+			env.PrependPath(system.SystemScope, conflict)
+			env.Admin = tt.admin
+
+			checkSystemPathConflicts(sdk)
+
+			if asked := len(*questions) > 0; asked != tt.wantAsked {
+				t.Fatalf("asked for confirmation = %v, want %v", asked, tt.wantAsked)
+			}
+			for _, question := range *questions {
+				if strings.Contains(question, system32) || strings.Contains(question, managed) {
+					t.Errorf("confirmation %q offers %s or the Windows directory for removal", question, managed)
+				}
+			}
+
+			want := []string{system32, other, managed}
+			if tt.wantKept {
+				want = []string{conflict, system32, other, managed}
+			}
+			if got := env.PathEntries(system.SystemScope); !reflect.DeepEqual(got, want) {
+				t.Errorf("System PATH = %v, want %v", got, want)
+			}
+		})
+	}
+}
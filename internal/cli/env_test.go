@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// newTestProject writes a project file to a temporary directory and makes a
+// subdirectory of it the working directory
+func newTestProject(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".unosdkrc"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(dir, "src")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+	return dir
+}
+
+func TestRunEnv(t *testing.T) {
+	env := newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	maven := addTestSDK(t, models.MavenSDK, "apache", "3.9.9")
+	newTestProject(t, "java: openjdk 17\nmaven: apache 3.9.9\n")
+
+	t.Setenv("PATH", strings.Join([]string{filepath.Join(java21.InstallPath, "bin"), "/usr/bin"}, string(os.PathListSeparator)))
+
+	envShell = "bash"
+	t.Cleanup(func() { envShell = "" })
+	var out bytes.Buffer
+	envCmd.SetOut(&out)
+	envCmd.SetErr(&bytes.Buffer{})
+	t.Cleanup(func() { envCmd.SetOut(nil); envCmd.SetErr(nil) })
+
+	if err := runEnv(envCmd, nil); err != nil {
+		t.Fatalf("runEnv() error = %v", err)
+	}
+
+	want := []string{
+		"export JAVA_HOME='" + java17.InstallPath + "'",
+		"export MAVEN_HOME='" + maven.InstallPath + "'",
+	}
+	for _, line := range want {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("output = %q, want line %q", out.String(), line)
+		}
+	}
+	path := strings.Join([]string{
+		filepath.Join(maven.InstallPath, "bin"),
+		filepath.Join(java17.InstallPath, "bin"),
+		"/usr/bin",
+	}, string(os.PathListSeparator))
+	if !strings.Contains(out.String(), "PATH='"+path+"'\n") {
+		t.Errorf("output = %q, want PATH %q", out.String(), path)
+	}
+
+	// The persistent environment is left alone
+	if got := env.PathEntries(system.UserScope); len(got) != 0 {
+		t.Errorf("User PATH = %v, want it empty", got)
+	}
+}
+
+func TestRunEnv_NotInstalled(t *testing.T) {
+	newTestEnv(t)
+	addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	newTestProject(t, "java: openjdk 17\n")
+
+	err := runEnv(envCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "unosdk env install") {
+		t.Errorf("runEnv() error = %v, want a hint to run 'unosdk env install'", err)
+	}
+}
+
+func TestRunEnv_NoProjectFile(t *testing.T) {
+	newTestEnv(t)
+	t.Chdir(t.TempDir())
+
+	if err := runEnv(envCmd, nil); err == nil {
+		t.Error("runEnv() should fail without a project file")
+	}
+}
+
+func TestResolveShim_ProjectPin(t *testing.T) {
+	newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	want17 := addTestExecutable(t, java17, "java")
+	want21 := addTestExecutable(t, java21, "java")
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.SetDefault(java21); err != nil {
+		t.Fatal(err)
+	}
+
+	// Outside a project the default is used
+	t.Chdir(t.TempDir())
+	if path, _, err := resolveShim("java"); err != nil || path != want21 {
+		t.Errorf("resolveShim() = %v, %v, want %v", path, err, want21)
+	}
+
+	newTestProject(t, "java: openjdk 17\n")
+	path, sdk, err := resolveShim("java")
+	if err != nil {
+		t.Fatalf("resolveShim() error = %v", err)
+	}
+	if path != want17 || sdk.Version != "17" {
+		t.Errorf("resolveShim() = %v (%v), want %v", path, sdk.Version, want17)
+	}
+}
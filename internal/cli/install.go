@@ -3,21 +3,14 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/javaquery/unosdk/internal/installer"
-	"github.com/javaquery/unosdk/internal/providers"
-	"github.com/javaquery/unosdk/internal/providers/c"
-	"github.com/javaquery/unosdk/internal/providers/cpp"
-	"github.com/javaquery/unosdk/internal/providers/flutter"
-	"github.com/javaquery/unosdk/internal/providers/go"
-	"github.com/javaquery/unosdk/internal/providers/gradle"
-	"github.com/javaquery/unosdk/internal/providers/java"
-	"github.com/javaquery/unosdk/internal/providers/maven"
-	"github.com/javaquery/unosdk/internal/providers/node"
-	"github.com/javaquery/unosdk/internal/providers/python"
 	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
@@ -43,6 +36,10 @@ Examples:
   # Install Node.js 20
   unosdk install node nodejs 20.10.0
 
+  # Install the newest Node.js LTS release, or the newest of the "Jod" line
+  unosdk install node nodejs lts
+  unosdk install node nodejs lts/jod
+
   # Install Python 3.12
   unosdk install python python 3.12.1
 
@@ -77,20 +74,10 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	version := args[2]
 
 	// Initialize provider registry
-	providerRegistry := providers.NewRegistry()
-	
-	// Register providers
-	providerRegistry.Register(java.NewAmazonCorrettoProvider())
-	providerRegistry.Register(java.NewOpenJDKProvider())
-	providerRegistry.Register(java.NewGraalVMProvider())
-	providerRegistry.Register(node.NewNodeJSProvider())
-	providerRegistry.Register(python.NewPythonProvider())
-	providerRegistry.Register(flutter.NewFlutterProvider())
-	providerRegistry.Register(maven.NewMavenProvider())
-	providerRegistry.Register(gradle.NewGradleProvider())
-	providerRegistry.Register(golang.NewGoProvider())
-	providerRegistry.Register(cpp.NewMinGWProvider())
-	providerRegistry.Register(c.NewMinGWProvider())
+	providerRegistry := providerCatalog()
+	if _, err := providerRegistry.Lookup(sdkType, providerName); err != nil {
+		return err
+	}
 
 	// Initialize installer
 	inst := installer.NewInstaller(providerRegistry)
@@ -104,23 +91,39 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Installing %s %s version %s...\n", sdkType, providerName, version)
 
 	// Install SDK
-	ctx := context.Background()
+	ctx, stop := interruptContext()
+	defer stop()
 	sdk, err := inst.Install(ctx, sdkType, providerName, version, installArch)
 	if err != nil {
 		return fmt.Errorf("installation failed: %w", err)
 	}
 
 	// Add to registry
-	if err := reg.Add(sdk); err != nil {
-		return fmt.Errorf("failed to register SDK: %w", err)
+	if err := registerInstall(inst, reg, sdk); err != nil {
+		return err
 	}
 
 	fmt.Printf("✓ Successfully installed %s %s %s\n", sdkType, providerName, version)
 	fmt.Printf("  Location: %s\n", sdk.InstallPath)
 
-	// Setup environment variables (Windows-specific)
-	if !skipEnvSetup && runtime.GOOS == "windows" {
-		// Cleanup existing PATH entries first
+	if setAsDefault {
+		if err := reg.SetDefault(sdk); err != nil {
+			fmt.Printf("⚠ Warning: Failed to record default SDK: %v\n", err)
+		}
+	}
+
+	// In shims mode the shims pick up the default, the environment stays as is
+	if shim.Enabled() {
+		if err := ensureDefault(reg, sdkType); err != nil {
+			fmt.Printf("⚠ Warning: Failed to record default SDK: %v\n", err)
+		}
+		if err := createShims(sdkType); err != nil {
+			fmt.Printf("⚠ Warning: Failed to create shims: %v\n", err)
+		} else {
+			fmt.Println("✓ Shims updated")
+		}
+	} else if !skipEnvSetup {
+		// Setup environment variables, cleaning up existing PATH entries first
 		if err := cleanupExistingSDKPaths(reg, sdk); err != nil {
 			fmt.Printf("⚠ Warning: Failed to cleanup existing PATH entries: %v\n", err)
 		}
@@ -141,4 +144,26 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// interruptContext returns a context that is cancelled by Ctrl+C, so that an
+// interrupted install cleans up after itself instead of leaving a partial tree
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// registerInstall adds an installed SDK to the registry. When that fails a
+// fresh download is removed again, an unregistered install is never kept.
+func registerInstall(inst *installer.Installer, reg *registry.Registry, sdk *models.SDK) error {
+	// The shims read the env spec from the registry instead of the providers
+	spec := providerEnvSpec(sdk.Type, sdk.Provider)
+	sdk.Env = &spec
 
+	if err := reg.Add(sdk); err != nil {
+		if sdk.Source == models.SourceDownload {
+			if rmErr := inst.Uninstall(sdk.InstallPath); rmErr != nil {
+				fmt.Printf("⚠ Warning: Failed to remove %s: %v\n", sdk.InstallPath, rmErr)
+			}
+		}
+		return fmt.Errorf("failed to register SDK: %w", err)
+	}
+	return nil
+}
@@ -6,16 +6,6 @@ import (
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
-	"github.com/javaquery/unosdk/internal/providers"
-	"github.com/javaquery/unosdk/internal/providers/c"
-	"github.com/javaquery/unosdk/internal/providers/cpp"
-	"github.com/javaquery/unosdk/internal/providers/flutter"
-	"github.com/javaquery/unosdk/internal/providers/go"
-	"github.com/javaquery/unosdk/internal/providers/gradle"
-	"github.com/javaquery/unosdk/internal/providers/java"
-	"github.com/javaquery/unosdk/internal/providers/maven"
-	"github.com/javaquery/unosdk/internal/providers/node"
-	"github.com/javaquery/unosdk/internal/providers/python"
 	"github.com/javaquery/unosdk/internal/registry"
 )
 
@@ -90,20 +80,7 @@ func listInstalled() error {
 }
 
 func listAvailable() error {
-	providerRegistry := providers.NewRegistry()
-	
-	// Register providers
-	providerRegistry.Register(java.NewAmazonCorrettoProvider())
-	providerRegistry.Register(java.NewOpenJDKProvider())
-	providerRegistry.Register(java.NewGraalVMProvider())
-	providerRegistry.Register(node.NewNodeJSProvider())
-	providerRegistry.Register(python.NewPythonProvider())
-	providerRegistry.Register(flutter.NewFlutterProvider())
-	providerRegistry.Register(maven.NewMavenProvider())
-	providerRegistry.Register(gradle.NewGradleProvider())
-	providerRegistry.Register(golang.NewGoProvider())
-	providerRegistry.Register(cpp.NewMinGWProvider())
-	providerRegistry.Register(c.NewMinGWProvider())
+	providerRegistry := providerCatalog()
 
 	allProviders := providerRegistry.ListAll()
 
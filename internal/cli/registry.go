@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryPublicKey     string
+	registryAllowUnsigned bool
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the SDK version registry",
+	Long: `Manage the SDK version registry.
+
+The registry is a signed manifest of SDK versions, download URLs and
+checksums. Once synced, providers use it instead of their compiled-in
+version lists, so new SDK releases do not require a new unosdk release.`,
+}
+
+var registrySyncCmd = &cobra.Command{
+	Use:   "sync [source]",
+	Short: "Download the latest registry manifest",
+	Long: `Download the registry manifest, verify its signature and store it in the cache.
+
+The source defaults to the configured registry URL. It can also be a
+manifest URL, a local directory or a manifest file; the signature is read
+from the same location with a .sig suffix.
+
+The manifest is verified with the key built into unosdk, or the
+registry.public_key of ~/.unosdk/config.yaml. The signature is cached with
+the manifest and checked again every time the manifest is used, so a
+mirror signed with its own key must be configured there.
+
+Examples:
+  # Sync from the default registry
+  unosdk registry sync
+
+  # Sync from a local mirror
+  unosdk registry sync http://localhost:8080/registry --public-key <base64-key>
+
+  # Sync from a file without signature verification
+  unosdk registry sync ./manifest.json --allow-unsigned`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRegistrySync,
+}
+
+func init() {
+	registrySyncCmd.Flags().StringVar(&registryPublicKey, "public-key", "", "Base64 ed25519 public key to verify the manifest with")
+	registrySyncCmd.Flags().BoolVar(&registryAllowUnsigned, "allow-unsigned", false, "Skip signature verification")
+	registryCmd.AddCommand(registrySyncCmd)
+}
+
+func runRegistrySync(cmd *cobra.Command, args []string) error {
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	source := cfg.RegistryURL
+	if len(args) > 0 {
+		source = args[0]
+	}
+
+	settings, err := cfg.LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	// The flags override the registry settings for this sync only
+	opts := registry.SettingsOptions(settings)
+	if registryPublicKey != "" {
+		opts.PublicKey = registryPublicKey
+	}
+	if registryAllowUnsigned {
+		opts.AllowUnsigned = true
+	}
+
+	if opts.AllowUnsigned {
+		fmt.Println("⚠ Signature verification disabled")
+	}
+
+	fmt.Printf("Syncing registry from %s...\n", source)
+	manifest, err := registry.SyncManifest(context.Background(), source, cfg.CacheDir, opts)
+	if err != nil {
+		return fmt.Errorf("failed to sync registry: %w", err)
+	}
+
+	versions := 0
+	for _, provider := range manifest.Providers {
+		versions += len(provider.Versions)
+	}
+
+	fmt.Printf("✓ Synced registry manifest: %d providers, %d versions\n", len(manifest.Providers), versions)
+
+	// The manifest is verified again whenever it is loaded, with the settings
+	if _, err := registry.LoadManifest(cfg.CacheDir, registry.SettingsOptions(settings)); err != nil {
+		fmt.Printf("⚠ Providers ignore the synced manifest until it verifies with the configured key: %v\n", err)
+		fmt.Printf("  Set registry.public_key or registry.allow_unsigned in %s to use it\n", cfg.SettingsPath())
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/javaquery/unosdk/internal/providers"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +14,9 @@ var (
 	buildDate string
 )
 
+// providerCatalog loads the provider catalog once per process
+var providerCatalog = sync.OnceValue(providers.Default)
+
 // SetVersionInfo sets the version information
 func SetVersionInfo(v, c, d string) {
 	version = v
@@ -21,7 +26,7 @@ func SetVersionInfo(v, c, d string) {
 
 var rootCmd = &cobra.Command{
 	Use:   "unosdk",
-	Short: "Multi-SDK installer for Windows",
+	Short: "Multi-SDK installer for Windows, Linux and macOS",
 	Long: `SDK Installer is a CLI tool to install and manage multiple SDKs from different providers.
 
 Supported SDKs:
@@ -43,6 +48,9 @@ Examples:
   # Switch to a different Java version
   unosdk switch java openjdk 21
 
+  # Update installed OpenJDK versions to the newest release of their major line
+  unosdk update java openjdk
+
   # List all available providers
   unosdk list
 
@@ -61,6 +69,16 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(uninstallCmd)
 	rootCmd.AddCommand(switchCmd)
+	rootCmd.AddCommand(useCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(currentCmd)
+	rootCmd.AddCommand(whichCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(registryCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(shimsCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	// Global flags
@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/javaquery/unosdk/internal/project"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var shimsCmd = &cobra.Command{
+	Use:   "shims",
+	Short: "Manage shim-based version switching",
+	Long: `Manage shim-based version switching.
+
+In shims mode ~/.unosdk/shims is added to PATH once and holds a launcher for
+every SDK tool (java, node, mvn, go, gcc, ...). A launcher looks up the
+active SDK when it runs and starts the real executable, so switch, install
+and uninstall only record the default SDK: no PATH rewrite, no terminal
+restart. Inside a project with a .unosdkrc or unosdk.yaml file, the shims
+run the versions the project pins instead, also when they come from
+.tool-versions, .sdkmanrc, .nvmrc, .python-version, .java-version or go.mod.`,
+}
+
+var shimsEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Switch to shims mode",
+	Long: `Create the shims of all installed SDK types, add the shims directory to the
+User PATH and take the SDK directories out of it. Run it again to refresh
+the shims after upgrading unosdk.`,
+	Args: cobra.NoArgs,
+	RunE: runShimsEnable,
+}
+
+var shimsDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Switch back to PATH-based version switching",
+	Long:  `Remove the shims directory and put the default SDKs back on the User PATH.`,
+	Args:  cobra.NoArgs,
+	RunE:  runShimsDisable,
+}
+
+func init() {
+	shimsCmd.AddCommand(shimsEnableCmd)
+	shimsCmd.AddCommand(shimsDisableCmd)
+}
+
+// RunShim runs the tool a shim was started as with the active SDK and
+// returns the exit code
+func RunShim(name string, args []string) int {
+	path, sdk, err := resolveShim(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unosdk: %v\n", err)
+		return 1
+	}
+
+	vars, dirs := recordedEnvSpec(sdk).Expand(sdk.InstallPath)
+	code, err := shim.Exec(path, args, shim.Environ(os.Environ(), vars, dirs))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unosdk: %v\n", err)
+	}
+	return code
+}
+
+// resolveShim finds the executable of a tool in the active SDK providing it:
+// the version pinned by the project of the working directory, otherwise the
+// default SDK
+func resolveShim(name string) (string, *models.SDK, error) {
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	var file *project.File
+	if dir, err := os.Getwd(); err == nil {
+		if file, err = project.Find(dir, defaultProviders()); err != nil {
+			return "", nil, err
+		}
+	}
+
+	types := shim.TypesFor(name)
+	if file != nil {
+		for _, sdkType := range types {
+			pin, ok := file.Pin(sdkType)
+			if !ok {
+				continue
+			}
+			sdk, err := resolvePin(context.Background(), reg, pin)
+			if err != nil {
+				return "", nil, err
+			}
+			_, dirs := recordedEnvSpec(sdk).Expand(sdk.InstallPath)
+			if path, ok := shim.FindExecutable(dirs, name); ok {
+				return path, sdk, nil
+			}
+		}
+	}
+
+	for _, sdkType := range types {
+		sdk, ok := reg.Default(sdkType)
+		if !ok {
+			continue
+		}
+		_, dirs := recordedEnvSpec(sdk).Expand(sdk.InstallPath)
+		if path, ok := shim.FindExecutable(dirs, name); ok {
+			return path, sdk, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("no default SDK provides %s, select one with 'unosdk switch'", name)
+}
+
+// recordedEnvSpec returns the env rules recorded in an SDK's registry entry,
+// or the defaults of its type for entries recorded before the rules were.
+// Shims run on every tool call, so they never load the provider catalog.
+func recordedEnvSpec(sdk *models.SDK) models.EnvSpec {
+	if sdk.Env != nil {
+		return *sdk.Env
+	}
+	return models.DefaultEnvSpec(sdk.Type, runtime.GOOS)
+}
+
+func runShimsEnable(cmd *cobra.Command, args []string) error {
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	dir, err := shim.Dir()
+	if err != nil {
+		return err
+	}
+
+	// Defaults are chosen from the current environment before shims mode is on
+	types := installedTypes(reg)
+	for _, sdkType := range types {
+		if err := ensureDefault(reg, sdkType); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shims directory: %w", err)
+	}
+	for _, sdkType := range types {
+		if err := createShims(sdkType); err != nil {
+			return err
+		}
+		if sdk, ok := reg.Default(sdkType); ok {
+			fmt.Printf("✓ %s shims use %s %s\n", sdkType, sdk.Provider, sdk.Version)
+		}
+	}
+
+	// The shims replace the SDK directories on PATH
+	engine := newEnvEngine()
+	for _, sdk := range reg.List() {
+		pathSpec := models.EnvSpec{Path: sdkEnvSpec(sdk).Path}
+		changes, err := engine.Remove(pathSpec, sdk.InstallPath)
+		printEnvChanges(changes)
+		if err != nil {
+			return fmt.Errorf("failed to cleanup existing PATH entries: %w", err)
+		}
+	}
+	if err := newEnvBackend().PrependPath(system.UserScope, dir); err != nil {
+		return fmt.Errorf("failed to add to User PATH: %w", err)
+	}
+	fmt.Println("  Added to User PATH: " + dir)
+
+	fmt.Println("\n✓ Shims enabled")
+	fmt.Println(reloadHint())
+	fmt.Println("  From then on, 'unosdk switch' takes effect immediately.")
+
+	return nil
+}
+
+func runShimsDisable(cmd *cobra.Command, args []string) error {
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	dir, err := shim.Dir()
+	if err != nil {
+		return err
+	}
+
+	if err := newEnvBackend().RemovePath(system.UserScope, dir); err != nil {
+		return fmt.Errorf("failed to remove from User PATH: %w", err)
+	}
+	fmt.Println("  Removed from User PATH: " + dir)
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove shims directory: %w", err)
+	}
+
+	// Put the default SDKs back on PATH
+	for _, sdkType := range installedTypes(reg) {
+		sdk, ok := reg.Default(sdkType)
+		if !ok {
+			continue
+		}
+		if err := setupSDKEnvironment(sdk, true); err != nil {
+			fmt.Printf("⚠ Warning: Failed to setup environment for %s %s %s: %v\n", sdk.Type, sdk.Provider, sdk.Version, err)
+		}
+	}
+
+	fmt.Println("\n✓ Shims disabled")
+	fmt.Println(reloadHint())
+
+	return nil
+}
+
+// createShims creates the shims of an SDK type
+func createShims(sdkType models.SDKType) error {
+	dir, err := shim.Dir()
+	if err != nil {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the unosdk executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(self); err == nil {
+		self = resolved
+	}
+
+	return shim.Create(dir, self, shim.Tools(sdkType))
+}
+
+// removeUnusedShims deletes the shims no installed SDK provides any more
+func removeUnusedShims(reg *registry.Registry) error {
+	dir, err := shim.Dir()
+	if err != nil {
+		return err
+	}
+
+	provided := make(map[string]bool)
+	for _, sdkType := range installedTypes(reg) {
+		for _, tool := range shim.Tools(sdkType) {
+			provided[tool] = true
+		}
+	}
+
+	var unused []string
+	for _, tool := range shim.AllTools() {
+		if !provided[tool] {
+			unused = append(unused, tool)
+		}
+	}
+	return shim.Remove(dir, unused)
+}
+
+// ensureDefault records a default for an SDK type that has none: the SDK
+// currently on PATH, otherwise the newest installed version
+func ensureDefault(reg *registry.Registry, sdkType models.SDKType) error {
+	if _, ok := reg.Default(sdkType); ok {
+		return nil
+	}
+
+	sdks := reg.ListByType(sdkType)
+	if len(sdks) == 0 {
+		return nil
+	}
+	sort.Slice(sdks, func(i, j int) bool {
+		return models.CompareVersions(sdks[i].Version, sdks[j].Version) > 0
+	})
+
+	chosen := sdks[0]
+	for _, sdk := range sdks {
+		if isDefaultSDK(sdk) {
+			chosen = sdk
+			break
+		}
+	}
+	return reg.SetDefault(chosen)
+}
+
+// installedTypes returns the SDK types with at least one installation, sorted
+func installedTypes(reg *registry.Registry) []models.SDKType {
+	seen := make(map[models.SDKType]bool)
+	var types []models.SDKType
+	for _, sdk := range reg.List() {
+		if !seen[sdk.Type] {
+			seen[sdk.Type] = true
+			types = append(types, sdk.Type)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// addTestExecutable creates a tool in an SDK's bin directory
+func addTestExecutable(t *testing.T, sdk *models.SDK, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	path := filepath.Join(sdk.InstallPath, "bin", name)
+	if err := os.WriteFile(path, []byte("tool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestShimsEnable(t *testing.T) {
+	env := newTestEnv(t)
+	addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "17"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if err := runShimsEnable(shimsEnableCmd, nil); err != nil {
+		t.Fatalf("runShimsEnable() error = %v", err)
+	}
+
+	dir, _ := shim.Dir()
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, []string{dir}) {
+		t.Errorf("PATH = %v, want only the shims directory %v", got, dir)
+	}
+	if _, ok := shim.FindExecutable([]string{dir}, "javac"); !ok {
+		t.Error("javac shim not created")
+	}
+
+	// Switching only records the default, the environment stays untouched
+	javaHome, _ := env.Get(system.UserScope, "JAVA_HOME")
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != javaHome {
+		t.Errorf("JAVA_HOME = %v, want it unchanged (%v)", got, javaHome)
+	}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, []string{dir}) {
+		t.Errorf("PATH = %v, want only the shims directory %v", got, dir)
+	}
+
+	want := addTestExecutable(t, java21, "java")
+	path, sdk, err := resolveShim("java")
+	if err != nil {
+		t.Fatalf("resolveShim() error = %v", err)
+	}
+	if path != want || sdk.InstallPath != java21.InstallPath {
+		t.Errorf("resolveShim() = %v (%v), want %v", path, sdk.InstallPath, want)
+	}
+}
+
+func TestResolveShim_RecordedEnvSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		project string
+	}{
+		{"default SDK", ""},
+		{"pinned by the project", "java: openjdk 21\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestEnv(t)
+			if tt.project != "" {
+				newTestProject(t, tt.project)
+			} else {
+				t.Chdir(t.TempDir())
+			}
+
+			// The shims only read the env spec recorded in the registry
+			previous := providerCatalog
+			providerCatalog = func() *providers.Registry {
+				t.Fatal("resolveShim() loaded the provider catalog")
+				return nil
+			}
+			t.Cleanup(func() { providerCatalog = previous })
+
+			home, _ := os.UserHomeDir()
+			sdk := &models.SDK{
+				Type:        models.JavaSDK,
+				Provider:    "openjdk",
+				Version:     "21",
+				InstallPath: filepath.Join(home, ".unosdk", "java", "openjdk", "21"),
+				Env:         &models.EnvSpec{Path: []string{"tools"}},
+			}
+			want := addForeignExecutable(t, filepath.Join(sdk.InstallPath, "tools"), "java")
+
+			reg, err := registry.NewRegistry()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := reg.Add(sdk); err != nil {
+				t.Fatal(err)
+			}
+			if err := reg.SetDefault(sdk); err != nil {
+				t.Fatal(err)
+			}
+
+			path, _, err := resolveShim("java")
+			if err != nil {
+				t.Fatalf("resolveShim() error = %v", err)
+			}
+			if path != want {
+				t.Errorf("resolveShim() = %v, want %v from the recorded PATH directory", path, want)
+			}
+		})
+	}
+}
+
+func TestRegisterInstall_RecordsEnvSpec(t *testing.T) {
+	newTestEnv(t)
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	home, _ := os.UserHomeDir()
+	sdk := &models.SDK{
+		Type:        models.MavenSDK,
+		Provider:    "apache",
+		Version:     "3.9.9",
+		InstallPath: filepath.Join(home, ".unosdk", "maven", "apache", "3.9.9"),
+	}
+	if err := registerInstall(nil, reg, sdk); err != nil {
+		t.Fatalf("registerInstall() error = %v", err)
+	}
+
+	reloaded, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, ok := reloaded.Get(models.MavenSDK, "apache", "3.9.9")
+	if !ok || stored.Env == nil {
+		t.Fatalf("registry entry = %+v, want the env spec recorded", stored)
+	}
+	if want := providerEnvSpec(models.MavenSDK, "apache"); !reflect.DeepEqual(*stored.Env, want) {
+		t.Errorf("recorded env spec = %+v, want %+v", *stored.Env, want)
+	}
+}
+
+func TestShimsEnable_KeepsActiveDefault(t *testing.T) {
+	newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	// The environment points at 17, but no default was recorded yet
+	if err := setupSDKEnvironment(java17, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := runShimsEnable(shimsEnableCmd, nil); err != nil {
+		t.Fatalf("runShimsEnable() error = %v", err)
+	}
+
+	reg, _ := registry.NewRegistry()
+	if got, ok := reg.Default(models.JavaSDK); !ok || got.InstallPath != java17.InstallPath {
+		t.Errorf("Default(java) = %v, want %v", got, java17.InstallPath)
+	}
+}
+
+func TestShimsDisable(t *testing.T) {
+	env := newTestEnv(t)
+	node := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+
+	if err := runShimsEnable(shimsEnableCmd, nil); err != nil {
+		t.Fatalf("runShimsEnable() error = %v", err)
+	}
+	if err := runUninstall(uninstallCmd, []string{"node", "nodejs", "20.10.0"}); err != nil {
+		t.Fatalf("runUninstall() error = %v", err)
+	}
+	dir, _ := shim.Dir()
+	if _, ok := shim.FindExecutable([]string{dir}, "node"); ok {
+		t.Error("node shim should be removed with the last Node.js installation")
+	}
+
+	node = addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+	if err := runSwitch(switchCmd, []string{"node", "nodejs", "20.10.0"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if err := runShimsDisable(shimsDisableCmd, nil); err != nil {
+		t.Fatalf("runShimsDisable() error = %v", err)
+	}
+
+	if shim.Enabled() {
+		t.Error("shims directory should be removed")
+	}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, sdkPathDirs(node)) {
+		t.Errorf("PATH = %v, want %v", got, sdkPathDirs(node))
+	}
+}
@@ -2,10 +2,10 @@ package cli
 
 import (
 	"fmt"
-	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
@@ -57,9 +57,9 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 	providerName := args[1]
 	version := args[2]
 
-	// Validate SDK type
-	if !isValidSDKType(sdkType) {
-		return fmt.Errorf("invalid SDK type: %s (valid types: java, node, python, go, maven, gradle, flutter, cpp, c)", sdkType)
+	// Validate SDK type and provider
+	if _, err := providerCatalog().Lookup(sdkType, providerName); err != nil {
+		return err
 	}
 
 	// Initialize registry
@@ -77,39 +77,38 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Switching to %s %s %s...\n", sdkType, providerName, version)
 
-	// Setup environment variables (Windows-specific)
-	if runtime.GOOS == "windows" {
-		// First, cleanup existing PATH entries for this SDK type
-		if err := cleanupExistingSDKPaths(reg, sdk); err != nil {
-			fmt.Printf("⚠ Warning: Failed to cleanup existing PATH entries: %v\n", err)
-		}
+	if err := reg.SetDefault(sdk); err != nil {
+		return fmt.Errorf("failed to record default SDK: %w", err)
+	}
 
-		// Setup environment variables for the target SDK (always set JAVA_HOME for switch)
-		if err := setupSDKEnvironment(sdk, true); err != nil {
-			return fmt.Errorf("failed to setup environment variables: %w", err)
+	// Shims resolve the default when they run, so nothing else changes
+	if shim.Enabled() {
+		if err := createShims(sdkType); err != nil {
+			return fmt.Errorf("failed to create shims: %w", err)
 		}
+		fmt.Printf("\n✓ Successfully switched to %s %s %s\n", sdkType, providerName, version)
+		fmt.Println("  The shims use it right away, no terminal restart needed.")
+		return nil
+	}
+
+	// First, cleanup existing PATH entries for this SDK type
+	if err := cleanupExistingSDKPaths(reg, sdk); err != nil {
+		fmt.Printf("⚠ Warning: Failed to cleanup existing PATH entries: %v\n", err)
+	}
 
-		fmt.Println("✓ Environment variables configured")
-		fmt.Printf("  Location: %s\n", sdk.InstallPath)
-		
-		// Check for conflicts with System PATH
-		checkSystemPathConflicts(sdk)
-	} else {
-		return fmt.Errorf("switch command is currently only supported on Windows")
+	// Setup environment variables for the target SDK (always set JAVA_HOME for switch)
+	if err := setupSDKEnvironment(sdk, true); err != nil {
+		return fmt.Errorf("failed to setup environment variables: %w", err)
 	}
 
+	fmt.Println("✓ Environment variables configured")
+	fmt.Printf("  Location: %s\n", sdk.InstallPath)
+
+	// Check for conflicts with System PATH
+	checkSystemPathConflicts(sdk)
+
 	fmt.Printf("\n✓ Successfully switched to %s %s %s\n", sdkType, providerName, version)
-	fmt.Println("Please restart your terminal for changes to take effect.")
+	fmt.Println(reloadHint())
 
 	return nil
 }
-
-// isValidSDKType checks if the SDK type is valid
-func isValidSDKType(sdkType models.SDKType) bool {
-	switch sdkType {
-	case models.JavaSDK, models.NodeSDK, models.PythonSDK, models.GoSDK, models.MavenSDK, models.GradleSDK, models.FlutterSDK, models.CppSDK, models.CSDK:
-		return true
-	default:
-		return false
-	}
-}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestRunSwitch(t *testing.T) {
+	env := newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "17"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != java21.InstallPath {
+		t.Errorf("JAVA_HOME = %v, want %v", got, java21.InstallPath)
+	}
+
+	// The previous version's bin directory is replaced, not shadowed
+	want := []string{filepath.Join(java21.InstallPath, "bin")}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v (old entry %v must be removed)", got, want, filepath.Join(java17.InstallPath, "bin"))
+	}
+}
+
+func TestRunSwitch_NotInstalled(t *testing.T) {
+	newTestEnv(t)
+
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err == nil {
+		t.Error("runSwitch() should fail for an SDK that is not installed")
+	}
+}
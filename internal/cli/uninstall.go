@@ -5,9 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/javaquery/unosdk/internal/installer"
-	"github.com/javaquery/unosdk/internal/providers"
 	"github.com/javaquery/unosdk/internal/registry"
-	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/internal/shim"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
@@ -58,7 +57,7 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Location: %s\n", sdk.InstallPath)
 
 	// Initialize installer
-	providerRegistry := providers.NewRegistry()
+	providerRegistry := providerCatalog()
 	inst := installer.NewInstaller(providerRegistry)
 
 	// Uninstall
@@ -66,6 +65,10 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("uninstallation failed: %w", err)
 	}
 
+	// The recorded default decides whether a new default must be chosen
+	recorded, hasDefault := reg.Default(sdkType)
+	wasRecordedDefault := hasDefault && recorded.InstallPath == sdk.InstallPath
+
 	// Remove from registry
 	if err := reg.Remove(sdkType, providerName, version); err != nil {
 		return fmt.Errorf("failed to remove from registry: %w", err)
@@ -73,9 +76,18 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("✓ Successfully uninstalled %s %s %s\n", sdkType, providerName, version)
 
+	if shim.Enabled() {
+		if err := removeUnusedShims(reg); err != nil {
+			fmt.Printf("⚠ Warning: Failed to remove shims: %v\n", err)
+		}
+	}
+
 	// Cleanup environment variables if requested
 	if cleanupEnv {
 		wasDefault, err := cleanupEnvironment(sdk)
+		if hasDefault || shim.Enabled() {
+			wasDefault = wasRecordedDefault
+		}
 		if err != nil {
 			fmt.Printf("⚠ Warning: Failed to cleanup environment variables: %v\n", err)
 		} else {
@@ -93,54 +105,17 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// cleanupEnvironment removes the SDK's variables and PATH entries and reports
+// whether they were all active, i.e. it was the default according to the
+// environment. That only decides for types without a recorded default.
 func cleanupEnvironment(sdk *models.SDK) (bool, error) {
-	env := system.NewWindowsEnv()
-	wasDefault := false
-
-	switch sdk.Type {
-	case models.JavaSDK:
-		// Check if this is the current JAVA_HOME
-		currentHome, err := env.GetJavaHome()
-		if err == nil && currentHome == sdk.InstallPath {
-			wasDefault = true
-			if err := env.DeleteUserEnvironmentVariable("JAVA_HOME"); err != nil {
-				return wasDefault, err
-			}
-			fmt.Println("  Removed JAVA_HOME")
-		}
+	engine := newEnvEngine()
+	spec := sdkEnvSpec(sdk)
+	wasDefault := len(engine.Diff(spec, sdk.InstallPath)) == 0
 
-		// Remove from PATH
-		binPath := sdk.InstallPath + "\\bin"
-		if err := env.RemoveFromPath(binPath); err != nil {
-			return wasDefault, err
-		}
-		fmt.Println("  Removed from PATH: " + binPath)
-
-	case models.NodeSDK:
-		// Remove from PATH
-		if err := env.RemoveFromPath(sdk.InstallPath); err != nil {
-			return wasDefault, err
-		}
-		wasDefault = true // Node/Python are default if they were in PATH
-		fmt.Println("  Removed from PATH: " + sdk.InstallPath)
-
-	case models.PythonSDK:
-		// Remove from PATH
-		if err := env.RemoveFromPath(sdk.InstallPath); err != nil {
-			return wasDefault, err
-		}
-		wasDefault = true // Node/Python are default if they were in PATH
-		fmt.Println("  Removed from PATH: " + sdk.InstallPath)
-
-		// Remove Scripts directory
-		scriptsPath := sdk.InstallPath + "\\Scripts"
-		if err := env.RemoveFromPath(scriptsPath); err != nil {
-			return wasDefault, err
-		}
-		fmt.Println("  Removed from PATH: " + scriptsPath)
-	}
-
-	return wasDefault, nil
+	changes, err := engine.Remove(spec, sdk.InstallPath)
+	printEnvChanges(changes)
+	return wasDefault, err
 }
 
 // setNewDefault attempts to set a new default SDK after uninstallation
@@ -179,12 +154,17 @@ func setNewDefault(reg *registry.Registry, sdkType models.SDKType, uninstalledPr
 		return fmt.Errorf("no alternative SDKs found")
 	}
 
-	// Setup environment for the new default
-	setJavaHome := (sdkType == models.JavaSDK)
-	if err := setupSDKEnvironment(newDefault, setJavaHome); err != nil {
+	if err := reg.SetDefault(newDefault); err != nil {
 		return fmt.Errorf("failed to set new default: %w", err)
 	}
 
+	// Setup environment for the new default, unless shims pick it up
+	if !shim.Enabled() {
+		if err := setupSDKEnvironment(newDefault, true); err != nil {
+			return fmt.Errorf("failed to set new default: %w", err)
+		}
+	}
+
 	fmt.Printf("✓ Successfully set %s %s %s as default\n", 
 		newDefault.Type, newDefault.Provider, newDefault.Version)
 	
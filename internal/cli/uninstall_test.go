@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestRunUninstall_PromotesNewDefault(t *testing.T) {
+	env := newTestEnv(t)
+	node18 := addTestSDK(t, models.NodeSDK, "nodejs", "18.19.0")
+	node20 := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+
+	if err := runSwitch(switchCmd, []string{"node", "nodejs", "20.10.0"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if err := runUninstall(uninstallCmd, []string{"node", "nodejs", "20.10.0"}); err != nil {
+		t.Fatalf("runUninstall() error = %v", err)
+	}
+
+	if _, err := os.Stat(node20.InstallPath); !os.IsNotExist(err) {
+		t.Errorf("install directory %s should be removed", node20.InstallPath)
+	}
+	reg, _ := registry.NewRegistry()
+	if _, ok := reg.Get(models.NodeSDK, "nodejs", "20.10.0"); ok {
+		t.Error("uninstalled SDK should be removed from the registry")
+	}
+
+	want := sdkPathDirs(node18)
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+}
+
+func TestRunUninstall_KeepsOtherDefault(t *testing.T) {
+	env := newTestEnv(t)
+	addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if err := runUninstall(uninstallCmd, []string{"java", "openjdk", "17"}); err != nil {
+		t.Fatalf("runUninstall() error = %v", err)
+	}
+
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != java21.InstallPath {
+		t.Errorf("JAVA_HOME = %v, want %v", got, java21.InstallPath)
+	}
+	want := []string{filepath.Join(java21.InstallPath, "bin")}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+}
+
+func TestRunUninstall_RemovesPathOfEveryType(t *testing.T) {
+	env := newTestEnv(t)
+	maven := addTestSDK(t, models.MavenSDK, "apache", "3.9.9")
+
+	if err := runSwitch(switchCmd, []string{"maven", "apache", "3.9.9"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, sdkPathDirs(maven)) {
+		t.Fatalf("PATH = %v, want %v", got, sdkPathDirs(maven))
+	}
+
+	if err := runUninstall(uninstallCmd, []string{"maven", "apache", "3.9.9"}); err != nil {
+		t.Fatalf("runUninstall() error = %v", err)
+	}
+	if got := env.PathEntries(system.UserScope); len(got) != 0 {
+		t.Errorf("PATH = %v, want the Maven bin directory removed", got)
+	}
+}
+
+func TestRunUninstall_UsesRecordedDefault(t *testing.T) {
+	env := newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	if err := runSwitch(switchCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runSwitch() error = %v", err)
+	}
+	// JAVA_HOME edited by hand no longer tells which SDK is the default
+	if err := env.Set(system.UserScope, "JAVA_HOME", "/opt/other-jdk"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runUninstall(uninstallCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runUninstall() error = %v", err)
+	}
+
+	reg, _ := registry.NewRegistry()
+	if got, ok := reg.Default(models.JavaSDK); !ok || got.InstallPath != java17.InstallPath {
+		t.Errorf("Default(java) = %v, %v, want %v", got, ok, java17.InstallPath)
+	}
+	want := []string{filepath.Join(java17.InstallPath, "bin")}
+	if got := env.PathEntries(system.UserScope); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+}
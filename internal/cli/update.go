@@ -1,34 +1,271 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"runtime"
+	"sort"
 
+	"github.com/javaquery/unosdk/internal/installer"
+	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/pkg/models"
 	"github.com/spf13/cobra"
 )
 
+var (
+	updateVersion string
+	updateArch    string
+	keepOld       bool
+	updateSkipEnv bool
+)
+
 var updateCmd = &cobra.Command{
-	Use:   "update [sdk]",
+	Use:   "update [sdk-type] [provider]",
 	Short: "Update an installed SDK to the latest version",
-	Long:  `Update an installed SDK to the latest version or a specific version.`,
-	Args:  cobra.MinimumNArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		sdkName := args[0]
-		version, _ := cmd.Flags().GetString("version")
-
-		fmt.Printf("Updating %s...\n", sdkName)
-		if version != "" {
-			fmt.Printf("Target version: %s\n", version)
+	Long: `Update an installed SDK to the newest release in the same major line,
+or to a specific version.
+
+The new version is installed and becomes the default if the version it
+replaces was the default. The old version is removed unless --keep-old is set.
+A specific version only replaces the installed versions of its major line.
+
+Examples:
+  # Update every installed OpenJDK major line (e.g. 21.0.9 -> 21.0.10)
+  unosdk update java openjdk
+
+  # Update Node.js to a specific version, keeping the old one
+  unosdk update node nodejs --version 24.11.1 --keep-old`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().StringVar(&updateVersion, "version", "", "Specific version to update to")
+	updateCmd.Flags().StringVar(&updateArch, "arch", runtime.GOARCH, "Architecture (x64, x86, arm64)")
+	updateCmd.Flags().BoolVar(&keepOld, "keep-old", false, "Keep the old version installed")
+	updateCmd.Flags().BoolVar(&updateSkipEnv, "skip-env", false, "Skip environment variable setup")
+}
+
+// sdkUpdate describes the installed versions replaced by a target version
+type sdkUpdate struct {
+	target   string
+	replaced []*models.SDK
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	sdkType := models.SDKType(args[0])
+	providerName := args[1]
+
+	providerRegistry := providerCatalog()
+	provider, err := providerRegistry.Lookup(sdkType, providerName)
+	if err != nil {
+		return err
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	var installed []*models.SDK
+	for _, sdk := range reg.ListByType(sdkType) {
+		if sdk.Provider == providerName {
+			installed = append(installed, sdk)
+		}
+	}
+	if len(installed) == 0 {
+		return fmt.Errorf("no installed versions of %s %s found\nInstall one first using: unosdk install %s %s <version>",
+			sdkType, providerName, sdkType, providerName)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	updates, err := planUpdates(ctx, provider, installed, updateVersion)
+	if err != nil {
+		return err
+	}
+
+	inst := installer.NewInstaller(providerRegistry)
+	upToDate := true
+	for _, update := range updates {
+		if _, exists := reg.Get(sdkType, providerName, update.target); exists {
+			fmt.Printf("✓ %s %s %s is already installed\n", sdkType, providerName, update.target)
+			continue
+		}
+		upToDate = false
+
+		if err := applyUpdate(ctx, inst, reg, sdkType, providerName, update); err != nil {
+			return err
+		}
+	}
+
+	if upToDate {
+		fmt.Println("\n✓ Already up to date")
+	} else {
+		fmt.Println("\n✓ Update complete!")
+	}
+	return nil
+}
+
+// planUpdates determines the target version for the installed versions. With
+// an explicit version the installed versions of its major line are replaced by
+// it, otherwise each major line is updated to its newest available release.
+func planUpdates(ctx context.Context, provider providers.Provider, installed []*models.SDK, version string) ([]sdkUpdate, error) {
+	if version != "" {
+		target, err := providers.ResolveVersion(ctx, provider, version)
+		if err != nil {
+			return nil, err
+		}
+
+		// Other major lines are left alone
+		var replaced []*models.SDK
+		for _, sdk := range installed {
+			if models.MajorVersion(sdk.Version) == models.MajorVersion(target) {
+				replaced = append(replaced, sdk)
+			}
+		}
+		return []sdkUpdate{{target: target, replaced: replaced}}, nil
+	}
+
+	available, err := provider.GetVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available versions: %w", err)
+	}
+
+	// Group installed versions by major line
+	lines := make(map[string][]*models.SDK)
+	for _, sdk := range installed {
+		major := models.MajorVersion(sdk.Version)
+		if major == "" {
+			fmt.Printf("⚠ Skipping %s %s %s: not a numeric version\n", sdk.Type, sdk.Provider, sdk.Version)
+			continue
+		}
+		lines[major] = append(lines[major], sdk)
+	}
+
+	majors := make([]string, 0, len(lines))
+	for major := range lines {
+		majors = append(majors, major)
+	}
+	sort.Slice(majors, func(i, j int) bool {
+		return models.CompareVersions(majors[i], majors[j]) > 0
+	})
+
+	var updates []sdkUpdate
+	for _, major := range majors {
+		newest := newestVersion(available, major)
+		if newest == "" {
+			fmt.Printf("⚠ No releases available in the %s line\n", major)
+			continue
+		}
+
+		// Only move forward: an installed version newer than the index wins
+		replaced := lines[major]
+		upToDate := false
+		for _, sdk := range replaced {
+			if models.CompareVersions(sdk.Version, newest) >= 0 {
+				upToDate = true
+			}
+		}
+		if upToDate {
+			fmt.Printf("✓ %s line is up to date\n", major)
+			continue
+		}
+
+		updates = append(updates, sdkUpdate{target: newest, replaced: replaced})
+	}
+
+	return updates, nil
+}
+
+// newestVersion returns the newest of versions in the given major line
+func newestVersion(versions []string, major string) string {
+	newest := ""
+	for _, v := range versions {
+		if models.MajorVersion(v) != major {
+			continue
+		}
+		if newest == "" || models.CompareVersions(v, newest) > 0 {
+			newest = v
+		}
+	}
+	return newest
+}
+
+// applyUpdate installs the target version, moves the default over to it and
+// removes the replaced versions and their environment unless --keep-old is set
+func applyUpdate(ctx context.Context, inst *installer.Installer, reg *registry.Registry, sdkType models.SDKType, providerName string, update sdkUpdate) error {
+	wasDefault := false
+	for _, sdk := range update.replaced {
+		if isDefaultSDK(sdk) {
+			wasDefault = true
+		}
+	}
+
+	fmt.Printf("Updating %s %s to %s...\n", sdkType, providerName, update.target)
+
+	sdk, err := inst.Install(ctx, sdkType, providerName, update.target, updateArch)
+	if err != nil {
+		return fmt.Errorf("installation failed: %w", err)
+	}
+	if err := registerInstall(inst, reg, sdk); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Successfully installed %s %s %s\n", sdkType, providerName, sdk.Version)
+	fmt.Printf("  Location: %s\n", sdk.InstallPath)
+
+	if wasDefault {
+		if err := reg.SetDefault(sdk); err != nil {
+			fmt.Printf("⚠ Warning: Failed to record default SDK: %v\n", err)
+		}
+	}
+
+	if wasDefault && shim.Enabled() {
+		if err := createShims(sdkType); err != nil {
+			fmt.Printf("⚠ Warning: Failed to create shims: %v\n", err)
+		} else {
+			fmt.Printf("✓ Switched default to %s %s %s\n", sdkType, providerName, sdk.Version)
+		}
+	} else if wasDefault && !updateSkipEnv {
+		// Take the other versions of the type off PATH; their variables are
+		// overwritten by the new default
+		if err := cleanupExistingSDKPaths(reg, sdk); err != nil {
+			fmt.Printf("⚠ Warning: Failed to cleanup existing PATH entries: %v\n", err)
+		}
+
+		if err := setupSDKEnvironment(sdk, true); err != nil {
+			fmt.Printf("⚠ Warning: Failed to setup environment variables: %v\n", err)
 		} else {
-			fmt.Println("Target version: latest")
+			fmt.Printf("✓ Switched default to %s %s %s\n", sdkType, providerName, sdk.Version)
+			checkSystemPathConflicts(sdk)
 		}
+	}
 
-		// TODO: Implement update logic
-		fmt.Println("Update functionality coming soon!")
+	if keepOld {
 		return nil
-	},
-}
+	}
 
-func init() {
-	updateCmd.Flags().StringP("version", "v", "", "Specific version to update to")
-	rootCmd.AddCommand(updateCmd)
+	for _, old := range update.replaced {
+		if old.InstallPath == sdk.InstallPath {
+			continue
+		}
+		// Like uninstall, and also with --skip-env: the variables and PATH
+		// entries of the removed version would point at a deleted directory
+		if _, err := cleanupEnvironment(old); err != nil {
+			fmt.Printf("⚠ Warning: Failed to cleanup environment variables of %s %s %s: %v\n", old.Type, old.Provider, old.Version, err)
+		}
+		if err := inst.Uninstall(old.InstallPath); err != nil {
+			fmt.Printf("⚠ Warning: Failed to remove %s %s %s: %v\n", old.Type, old.Provider, old.Version, err)
+			continue
+		}
+		if err := reg.Remove(old.Type, old.Provider, old.Version); err != nil {
+			return fmt.Errorf("failed to remove from registry: %w", err)
+		}
+		fmt.Printf("✓ Removed %s %s %s\n", old.Type, old.Provider, old.Version)
+	}
+
+	return nil
 }
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/installer"
+	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// releasesProvider is a provider offering a fixed list of releases,
+// installed below root
+type releasesProvider struct {
+	versions []string
+	root     string
+}
+
+func (p *releasesProvider) Name() string         { return "openjdk" }
+func (p *releasesProvider) DisplayName() string  { return "OpenJDK" }
+func (p *releasesProvider) Type() models.SDKType { return models.JavaSDK }
+func (p *releasesProvider) GetVersions(ctx context.Context) ([]string, error) {
+	return p.versions, nil
+}
+func (p *releasesProvider) GetLatestVersion(ctx context.Context) (string, error) {
+	return p.versions[0], nil
+}
+func (p *releasesProvider) GetDownloadURL(version, arch string) (string, error) { return "", nil }
+func (p *releasesProvider) GetChecksum(ctx context.Context, version, arch string) (string, error) {
+	return "", nil
+}
+func (p *releasesProvider) GetDefaultInstallPath(version string) string {
+	return filepath.Join(p.root, version)
+}
+func (p *releasesProvider) Validate(version string) error { return nil }
+
+func TestPlanUpdates(t *testing.T) {
+	provider := &releasesProvider{versions: []string{"21.0.10", "21.0.9", "17.0.18", "17.0.17"}}
+	installed := []*models.SDK{
+		{Type: models.JavaSDK, Provider: "openjdk", Version: "21.0.9"},
+		{Type: models.JavaSDK, Provider: "openjdk", Version: "17.0.17"},
+		{Type: models.JavaSDK, Provider: "openjdk", Version: "11.0.25"},
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		// want maps each target to the versions it replaces
+		want map[string][]string
+	}{
+		{"newest release per line", "", map[string][]string{
+			"21.0.10": {"21.0.9"},
+			"17.0.18": {"17.0.17"},
+		}},
+		{"explicit version replaces its line only", "21.0.5", map[string][]string{
+			"21.0.5": {"21.0.9"},
+		}},
+		{"explicit version of a new line replaces nothing", "25.0.2", map[string][]string{
+			"25.0.2": nil,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := planUpdates(context.Background(), provider, installed, tt.version)
+			if err != nil {
+				t.Fatalf("planUpdates() error = %v", err)
+			}
+
+			got := make(map[string][]string)
+			for _, update := range updates {
+				var replaced []string
+				for _, sdk := range update.replaced {
+					replaced = append(replaced, sdk.Version)
+				}
+				got[update.target] = replaced
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planUpdates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyUpdate_CleansReplacedEnvironment(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     bool
+		skipEnv bool
+	}{
+		{name: "replaced version was not the default", def: false},
+		{name: "default replaced with --skip-env", def: true, skipEnv: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			updateSkipEnv = tt.skipEnv
+			t.Cleanup(func() { updateSkipEnv = false })
+
+			java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17.0.17")
+			old := addTestSDK(t, models.JavaSDK, "openjdk", "21.0.9")
+			oldBin := sdkPathDirs(old)[0]
+			env.PrependPath(system.UserScope, oldBin)
+			reg, err := registry.NewRegistry()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.def {
+				env.Set(system.UserScope, "JAVA_HOME", old.InstallPath)
+				reg.SetDefault(old)
+			} else {
+				reg.SetDefault(java17)
+			}
+
+			// The target is already in place, so Install adopts it
+			home, _ := os.UserHomeDir()
+			root := filepath.Join(home, ".unosdk", "java", "openjdk")
+			addForeignExecutable(t, filepath.Join(root, "21.0.10", "bin"), "java")
+			os.WriteFile(filepath.Join(root, "21.0.10", "release"), []byte("JAVA_VERSION=21"), 0644)
+			catalog := providers.NewRegistry()
+			catalog.Register(&releasesProvider{versions: []string{"21.0.10"}, root: root})
+			inst := installer.NewInstaller(catalog)
+
+			update := sdkUpdate{target: "21.0.10", replaced: []*models.SDK{old}}
+			if err := applyUpdate(context.Background(), inst, reg, models.JavaSDK, "openjdk", update); err != nil {
+				t.Fatalf("applyUpdate() error = %v", err)
+			}
+
+			if system.ContainsPath(env.PathEntries(system.UserScope), oldBin) {
+				t.Errorf("User PATH = %v, still lists the removed %s", env.PathEntries(system.UserScope), oldBin)
+			}
+			if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got == old.InstallPath {
+				t.Errorf("JAVA_HOME = %v, still points at the removed version", got)
+			}
+			if _, err := os.Stat(old.InstallPath); !os.IsNotExist(err) {
+				t.Errorf("replaced version %s was not removed", old.InstallPath)
+			}
+		})
+	}
+}
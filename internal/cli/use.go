@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shell"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var useShell string
+
+var useCmd = &cobra.Command{
+	Use:   "use [sdk-type] [[provider] [version]]",
+	Short: "Use an SDK version in the current shell only",
+	Long: `Use an installed SDK version in the current shell session only.
+
+Unlike switch, use leaves the persistent environment untouched: it prints
+shell code that sets the SDK's variables (e.g. JAVA_HOME) and puts it in front
+of PATH. The wrapper function from 'unosdk init' evaluates that code, so
+add it to your shell profile once:
+
+  bash:        eval "$(unosdk init bash)"                              in ~/.bashrc
+  zsh:         eval "$(unosdk init zsh)"                               in ~/.zshrc
+  fish:        unosdk init fish | source                               in config.fish
+  PowerShell:  unosdk init powershell | Out-String | Invoke-Expression in $PROFILE
+  cmd:         for /f "delims=" %%i in ('unosdk init cmd') do @%%i     in an AutoRun script
+
+Without a provider and version, the version pinned by the project is used
+(see 'unosdk env').
+
+Examples:
+  # Use Java OpenJDK 17 in this terminal
+  unosdk use java openjdk 17
+
+  # Use the Node.js version of the project's .nvmrc
+  unosdk use node
+
+  # Print the code for a specific shell
+  unosdk use node nodejs 20.10.0 --shell fish`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 && len(args) != 3 {
+			return fmt.Errorf("accepts 1 or 3 arg(s), received %d", len(args))
+		}
+		return nil
+	},
+	RunE: runUse,
+}
+
+var initCmd = &cobra.Command{
+	Use:       "init [shell]",
+	Short:     "Print the shell wrapper that makes 'unosdk use' work",
+	Long:      `Print the wrapper function for bash, zsh, fish, powershell or cmd. Without the wrapper, 'unosdk use' can only print the code, as a program cannot change the environment of the shell that started it.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell", "cmd"},
+	RunE:      runInit,
+}
+
+func init() {
+	useCmd.Flags().StringVar(&useShell, "shell", "", "Shell to print code for (bash, zsh, fish, powershell, cmd), detected by default")
+}
+
+func runUse(cmd *cobra.Command, args []string) error {
+	sh, err := resolveShell(useShell)
+	if err != nil {
+		return err
+	}
+
+	// Initialize registry
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	var sdk *models.SDK
+	if len(args) == 1 {
+		if sdk, err = projectSDK(reg, models.SDKType(args[0])); err != nil {
+			return err
+		}
+	} else if sdk, err = installedSDK(reg, models.SDKType(args[0]), args[1], args[2]); err != nil {
+		return err
+	}
+	sdkType, providerName, version := sdk.Type, sdk.Provider, sdk.Version
+
+	vars, path := sessionEnv(reg, sdk, os.Getenv("PATH"))
+	fmt.Fprint(cmd.OutOrStdout(), sh.Render(vars, path))
+
+	// Messages go to stderr, stdout is evaluated by the wrapper
+	fmt.Fprintf(cmd.ErrOrStderr(), "✓ Using %s %s %s in this shell\n", sdkType, providerName, version)
+	if isTerminal(os.Stdout) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠ The code above was only printed. Add %s to %s to apply it automatically.\n",
+			sh.InitLine(), sh.Profile())
+	}
+
+	return nil
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	sh, err := resolveShell(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), sh.Hook())
+	return nil
+}
+
+// installedSDK looks up an installed SDK
+func installedSDK(reg *registry.Registry, sdkType models.SDKType, providerName, version string) (*models.SDK, error) {
+	// Validate SDK type and provider
+	if _, err := providerCatalog().Lookup(sdkType, providerName); err != nil {
+		return nil, err
+	}
+
+	sdk, exists := reg.Get(sdkType, providerName, version)
+	if !exists {
+		return nil, fmt.Errorf("SDK not found: %s %s %s\nPlease install it first using: unosdk install %s %s %s",
+			sdkType, providerName, version, sdkType, providerName, version)
+	}
+	return sdk, nil
+}
+
+// projectSDK returns the installed SDK the project pins for a type
+func projectSDK(reg *registry.Registry, sdkType models.SDKType) (*models.SDK, error) {
+	file, err := findProjectFile()
+	if err != nil {
+		return nil, err
+	}
+	pin, ok := file.Pin(sdkType)
+	if !ok {
+		return nil, fmt.Errorf("the project pins no %s version, pass a provider and version", sdkType)
+	}
+	return resolvePin(context.Background(), reg, pin)
+}
+
+// resolveShell parses a shell name, detecting the shell when it is empty
+func resolveShell(name string) (shell.Shell, error) {
+	if name == "" {
+		return shell.Detect(), nil
+	}
+	return shell.Parse(name)
+}
+
+// sessionEnv returns the variables and the PATH entries that activate an SDK
+// in a shell whose PATH is currentPath. Directories of other installations
+// of the same type are dropped, so they cannot shadow the SDK.
+func sessionEnv(reg *registry.Registry, sdk *models.SDK, currentPath string) (map[string]string, []string) {
+	vars, dirs := sdkEnvSpec(sdk).Expand(sdk.InstallPath)
+
+	var stale []string
+	for _, other := range reg.ListByType(sdk.Type) {
+		if other.InstallPath == sdk.InstallPath {
+			continue
+		}
+		_, otherDirs := sdkEnvSpec(other).Expand(other.InstallPath)
+		stale = append(stale, otherDirs...)
+	}
+
+	path := append([]string{}, dirs...)
+	for _, entry := range filepath.SplitList(currentPath) {
+		if entry == "" || system.ContainsPath(stale, entry) || system.ContainsPath(dirs, entry) {
+			continue
+		}
+		path = append(path, entry)
+	}
+	return vars, path
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestSessionEnv(t *testing.T) {
+	newTestEnv(t)
+	java17 := addTestSDK(t, models.JavaSDK, "openjdk", "17")
+	java21 := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	node := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	java17Bin := filepath.Join(java17.InstallPath, "bin")
+	java21Bin := filepath.Join(java21.InstallPath, "bin")
+	nodeBin := filepath.Join(node.InstallPath, "bin")
+	current := strings.Join([]string{java21Bin, nodeBin, java17Bin, "/usr/bin"}, string(filepath.ListSeparator))
+
+	vars, path := sessionEnv(reg, java17, current)
+
+	if vars["JAVA_HOME"] != java17.InstallPath {
+		t.Errorf("JAVA_HOME = %v, want %v", vars["JAVA_HOME"], java17.InstallPath)
+	}
+	// The SDK moves to the front, the other Java drops out, the rest stays in order
+	want := []string{java17Bin, nodeBin, "/usr/bin"}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("PATH = %v, want %v", path, want)
+	}
+}
+
+func TestRunUse(t *testing.T) {
+	env := newTestEnv(t)
+	java := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+
+	useShell = "bash"
+	t.Cleanup(func() { useShell = "" })
+
+	var out bytes.Buffer
+	useCmd.SetOut(&out)
+	useCmd.SetErr(&bytes.Buffer{})
+	t.Cleanup(func() { useCmd.SetOut(nil); useCmd.SetErr(nil) })
+
+	if err := runUse(useCmd, []string{"java", "openjdk", "21"}); err != nil {
+		t.Fatalf("runUse() error = %v", err)
+	}
+	if want := "export JAVA_HOME='" + java.InstallPath + "'\n"; !strings.HasPrefix(out.String(), want) {
+		t.Errorf("output = %q, want prefix %q", out.String(), want)
+	}
+
+	// The persistent environment is left alone
+	if got, _ := env.Get(system.UserScope, "JAVA_HOME"); got != "" {
+		t.Errorf("User JAVA_HOME = %v, want it unset", got)
+	}
+	if got := env.PathEntries(system.UserScope); len(got) != 0 {
+		t.Errorf("User PATH = %v, want it empty", got)
+	}
+}
+
+func TestRunUse_NotInstalled(t *testing.T) {
+	newTestEnv(t)
+
+	if err := runUse(useCmd, []string{"java", "openjdk", "21"}); err == nil {
+		t.Error("runUse() should fail for an SDK that is not installed")
+	}
+}
+
+func TestRunUse_ProjectVersion(t *testing.T) {
+	newTestEnv(t)
+	addTestSDK(t, models.NodeSDK, "nodejs", "18.19.0")
+	node20 := addTestSDK(t, models.NodeSDK, "nodejs", "20.10.0")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".nvmrc"), []byte("v20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	useShell = "fish"
+	t.Cleanup(func() { useShell = "" })
+	var out bytes.Buffer
+	useCmd.SetOut(&out)
+	useCmd.SetErr(&bytes.Buffer{})
+	t.Cleanup(func() { useCmd.SetOut(nil); useCmd.SetErr(nil) })
+
+	if err := runUse(useCmd, []string{"node"}); err != nil {
+		t.Fatalf("runUse() error = %v", err)
+	}
+	if want := "set -gx NODE_HOME '" + node20.InstallPath + "'\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+
+	if err := runUse(useCmd, []string{"java"}); err == nil {
+		t.Error("runUse() should fail for a type the project does not pin")
+	}
+}
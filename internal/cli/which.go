@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/internal/shim"
+	"github.com/javaquery/unosdk/internal/system"
+	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var whichCmd = &cobra.Command{
+	Use:   "which <executable>",
+	Short: "Show which installation provides an executable",
+	Long: `Show the full path an executable resolves to on PATH and the installation
+that owns it: a unosdk SDK, a unosdk shim (with the SDK it runs) or a
+foreign install. Other matches further down PATH are listed as shadowed.
+
+Examples:
+  unosdk which java
+  unosdk which mvn`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhich,
+}
+
+func runWhich(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	}
+
+	reg, err := registry.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to initialize registry: %w", err)
+	}
+
+	matches := findAllExecutables(filepath.SplitList(os.Getenv("PATH")), name)
+	if len(matches) == 0 {
+		return fmt.Errorf("%s not found on PATH", name)
+	}
+
+	w := cmd.OutOrStdout()
+	_, description := executableOwner(reg, name, matches[0])
+	fmt.Fprintln(w, matches[0])
+	fmt.Fprintf(w, "  %s\n", description)
+
+	if len(matches) > 1 {
+		fmt.Fprintln(w, "\nShadowed by the above:")
+		for _, path := range matches[1:] {
+			_, description := executableOwner(reg, name, path)
+			fmt.Fprintf(w, "  %s (%s)\n", path, description)
+		}
+	}
+	return nil
+}
+
+// findAllExecutables returns every match of an executable in dirs, in PATH
+// order
+func findAllExecutables(dirs []string, name string) []string {
+	var searched, matches []string
+	for _, dir := range dirs {
+		if dir == "" || system.ContainsPath(searched, dir) {
+			continue
+		}
+		searched = append(searched, dir)
+		if path, ok := shim.FindExecutable([]string{dir}, name); ok {
+			matches = append(matches, path)
+		}
+	}
+	return matches
+}
+
+// executableOwner returns the SDK an executable belongs to and a description
+// of its owner. Shims belong to the SDK they run; foreign installs have no
+// SDK.
+func executableOwner(reg *registry.Registry, name, path string) (*models.SDK, string) {
+	if dir, err := shim.Dir(); err == nil && isWithinDir(dir, path) {
+		target, sdk, err := resolveShim(name)
+		if err != nil {
+			return nil, fmt.Sprintf("unosdk shim, %v", err)
+		}
+		return sdk, fmt.Sprintf("unosdk shim running %s from %s %s %s", target, sdk.Type, sdk.Provider, sdk.Version)
+	}
+
+	if sdk := sdkContaining(reg, path); sdk != nil {
+		return sdk, fmt.Sprintf("unosdk %s %s %s", sdk.Type, sdk.Provider, sdk.Version)
+	}
+
+	// Package managers link executables into a shared bin directory
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = path
+	}
+	if sdk := sdkContaining(reg, resolved); sdk != nil {
+		return sdk, fmt.Sprintf("unosdk %s %s %s", sdk.Type, sdk.Provider, sdk.Version)
+	}
+	return nil, "foreign install at " + installRoot(resolved)
+}
+
+// sdkContaining returns the installed SDK whose directory holds path
+func sdkContaining(reg *registry.Registry, path string) *models.SDK {
+	for _, sdk := range reg.List() {
+		if sdk.InstallPath != "" && isWithinDir(sdk.InstallPath, path) {
+			return sdk
+		}
+	}
+	return nil
+}
+
+// installRoot returns the installation directory of an executable: the
+// parent of its bin directory, otherwise the directory holding it
+func installRoot(path string) string {
+	dir := filepath.Dir(path)
+	if strings.EqualFold(filepath.Base(dir), "bin") {
+		return filepath.Dir(dir)
+	}
+	return dir
+}
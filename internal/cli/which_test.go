@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestRunWhich(t *testing.T) {
+	newTestEnv(t)
+	java := addTestSDK(t, models.JavaSDK, "openjdk", "21")
+	owned := addTestExecutable(t, java, "java")
+
+	foreign := filepath.Join(t.TempDir(), "jdk-17")
+	addForeignExecutable(t, filepath.Join(foreign, "bin"), "java")
+
+	t.Setenv("PATH", strings.Join([]string{filepath.Dir(owned), filepath.Join(foreign, "bin")}, string(os.PathListSeparator)))
+
+	var out bytes.Buffer
+	whichCmd.SetOut(&out)
+	t.Cleanup(func() { whichCmd.SetOut(nil) })
+
+	if err := runWhich(whichCmd, []string{"java"}); err != nil {
+		t.Fatalf("runWhich() error = %v", err)
+	}
+	for _, want := range []string{
+		owned + "\n  unosdk java openjdk 21\n",
+		"foreign install at " + foreign,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestRunWhich_NotFound(t *testing.T) {
+	newTestEnv(t)
+	t.Setenv("PATH", t.TempDir())
+
+	if err := runWhich(whichCmd, []string{"java"}); err == nil {
+		t.Error("runWhich() should fail for an executable that is not on PATH")
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 const (
-	// DefaultRegistryURL is the default registry URL for SDK metadata
-	DefaultRegistryURL = "https://api.github.com/repos/javaquery/unosdk-registry"
+	// DefaultRegistryURL is the default registry URL for SDK metadata. The
+	// signed manifest is published at {RegistryURL}/manifest.json.
+	DefaultRegistryURL = "https://raw.githubusercontent.com/javaquery/unosdk-registry/main"
 )
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings holds the user preferences from ~/.unosdk/config.yaml
+type Settings struct {
+	// DefaultProviders maps SDK types to the provider used for versions read
+	// from files that name no provider, such as .nvmrc (e.g. java: amazoncorretto)
+	DefaultProviders map[string]string `yaml:"default_providers"`
+	// Registry controls how registry manifests are verified
+	Registry RegistrySettings `yaml:"registry"`
+}
+
+// RegistrySettings configures the verification of registry manifests, e.g.
+// for a mirror signed with its own key
+type RegistrySettings struct {
+	// PublicKey is the base64 ed25519 key used instead of the built-in one
+	PublicKey string `yaml:"public_key"`
+	// AllowUnsigned accepts manifests without a valid signature
+	AllowUnsigned bool `yaml:"allow_unsigned"`
+}
+
+// SettingsPath returns the path of the settings file
+func (c *Config) SettingsPath() string {
+	return filepath.Join(c.ConfigDir, "config.yaml")
+}
+
+// LoadSettings reads the settings file. A missing file yields empty settings.
+func (c *Config) LoadSettings() (*Settings, error) {
+	settings := &Settings{}
+
+	data, err := os.ReadFile(c.SettingsPath())
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", c.SettingsPath(), err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,203 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/config"
+)
+
+// cacheEntryFile holds the metadata of a cached download next to the archive
+const cacheEntryFile = "download.json"
+
+// DownloadCache keeps downloaded archives between installs, one directory per
+// URL and checksum. Archives are downloaded into the cache directly, so an
+// interrupted download is resumed by the next install.
+type DownloadCache struct {
+	dir string
+}
+
+// CachedDownload describes a cached archive. It is Complete once the download
+// finished and Verified once it matched the vendor checksum.
+type CachedDownload struct {
+	URL      string    `json:"url"`
+	Checksum string    `json:"checksum,omitempty"`
+	File     string    `json:"file"`
+	Complete bool      `json:"complete"`
+	Verified bool      `json:"verified"`
+	LastUsed time.Time `json:"last_used"`
+	// Size is the size of the archive on disk, partial downloads included
+	Size int64 `json:"-"`
+
+	dir string
+}
+
+// NewDownloadCache creates a download cache in dir
+func NewDownloadCache(dir string) *DownloadCache {
+	return &DownloadCache{dir: dir}
+}
+
+// NewDefaultDownloadCache creates a download cache below the unosdk cache
+// directory
+func NewDefaultDownloadCache() *DownloadCache {
+	cacheDir := filepath.Join(os.TempDir(), "unosdk-cache")
+	if cfg, err := config.New(); err == nil {
+		cacheDir = cfg.CacheDir
+	}
+	return NewDownloadCache(filepath.Join(cacheDir, "downloads"))
+}
+
+// Dir returns the directory of the cache
+func (c *DownloadCache) Dir() string {
+	return c.dir
+}
+
+// Path returns the path of the archive
+func (d *CachedDownload) Path() string {
+	return filepath.Join(d.dir, d.File)
+}
+
+// Reusable reports whether the archive can be installed without downloading
+// it again: it was verified against the checksum it is cached for
+func (d *CachedDownload) Reusable() bool {
+	if !d.Complete || !d.Verified || d.Checksum == "" {
+		return false
+	}
+	_, err := os.Stat(d.Path())
+	return err == nil
+}
+
+// Lookup returns the cache entry of a download, a new one if the URL and
+// checksum were never downloaded
+func (c *DownloadCache) Lookup(url, checksum string) *CachedDownload {
+	sum := sha256.Sum256([]byte(url + "\n" + checksum))
+	dir := filepath.Join(c.dir, hex.EncodeToString(sum[:8]))
+
+	if entry, err := readCacheEntry(dir); err == nil && entry.URL == url && entry.Checksum == checksum {
+		return entry
+	}
+	return &CachedDownload{URL: url, Checksum: checksum, File: path.Base(url), dir: dir}
+}
+
+// Save records the state of a download and marks it as used now
+func (c *DownloadCache) Save(entry *CachedDownload) error {
+	entry.LastUsed = time.Now()
+	return writeCacheEntry(entry)
+}
+
+// writeCacheEntry writes the metadata of a download next to the archive
+func writeCacheEntry(entry *CachedDownload) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	if err := os.MkdirAll(entry.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(entry.dir, cacheEntryFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes a download and its metadata
+func (c *DownloadCache) Remove(entry *CachedDownload) error {
+	if err := os.RemoveAll(entry.dir); err != nil {
+		return fmt.Errorf("failed to remove cached download: %w", err)
+	}
+	return nil
+}
+
+// List returns the cached downloads, least recently used first
+func (c *DownloadCache) List() ([]*CachedDownload, error) {
+	dirs, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download cache: %w", err)
+	}
+
+	var entries []*CachedDownload
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := readCacheEntry(filepath.Join(c.dir, dir.Name()))
+		if err != nil {
+			// A directory without metadata is kept as an entry so that it
+			// can be cleaned up
+			entry = &CachedDownload{File: dir.Name(), dir: filepath.Join(c.dir, dir.Name())}
+			if info, err := dir.Info(); err == nil {
+				entry.LastUsed = info.ModTime()
+			}
+		}
+		if info, err := os.Stat(entry.Path()); err == nil && !info.IsDir() {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+// Prune removes the downloads not used since before the cutoff and returns
+// them
+func (c *DownloadCache) Prune(cutoff time.Time) ([]*CachedDownload, error) {
+	return c.removeWhere(func(entry *CachedDownload) bool {
+		return entry.LastUsed.Before(cutoff)
+	})
+}
+
+// Clean removes every download and returns them
+func (c *DownloadCache) Clean() ([]*CachedDownload, error) {
+	return c.removeWhere(func(*CachedDownload) bool { return true })
+}
+
+// removeWhere removes the downloads matching remove and returns them
+func (c *DownloadCache) removeWhere(remove func(*CachedDownload) bool) ([]*CachedDownload, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []*CachedDownload
+	for _, entry := range entries {
+		if !remove(entry) {
+			continue
+		}
+		if err := c.Remove(entry); err != nil {
+			return removed, err
+		}
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+// readCacheEntry reads the metadata of the download in dir
+func readCacheEntry(dir string) (*CachedDownload, error) {
+	data, err := os.ReadFile(filepath.Join(dir, cacheEntryFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry CachedDownload
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	if entry.File == "" || entry.File != filepath.Base(entry.File) {
+		return nil, fmt.Errorf("invalid cache entry in %s", dir)
+	}
+	entry.dir = dir
+	return &entry, nil
+}
@@ -0,0 +1,119 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// addCachedDownload saves a cache entry with an archive of size bytes that
+// was last used at lastUsed
+func addCachedDownload(t *testing.T, cache *DownloadCache, url string, size int, lastUsed time.Time) *CachedDownload {
+	t.Helper()
+	download := cache.Lookup(url, "sha256:"+url)
+	if err := cache.Save(download); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := os.WriteFile(download.Path(), make([]byte, size), 0644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	// Save always records the current time
+	download.LastUsed = lastUsed
+	if err := writeCacheEntry(download); err != nil {
+		t.Fatalf("failed to write cache entry: %v", err)
+	}
+	return download
+}
+
+func TestDownloadCache_Lookup(t *testing.T) {
+	cache := NewDownloadCache(t.TempDir())
+
+	download := cache.Lookup("https://example.com/dist/jdk-21.tar.gz", "sha256:abc")
+	if download.File != "jdk-21.tar.gz" {
+		t.Errorf("Lookup() file = %v, want jdk-21.tar.gz", download.File)
+	}
+	if download.Complete || download.Reusable() {
+		t.Errorf("Lookup() of a new download should be neither complete nor reusable")
+	}
+
+	download.Complete = true
+	download.Verified = true
+	if err := cache.Save(download); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	os.WriteFile(download.Path(), []byte("archive"), 0644)
+
+	if got := cache.Lookup("https://example.com/dist/jdk-21.tar.gz", "sha256:abc"); !got.Reusable() {
+		t.Errorf("Lookup() of a verified download should be reusable")
+	}
+	if got := cache.Lookup("https://example.com/dist/jdk-21.tar.gz", "sha256:def"); got.Path() == download.Path() {
+		t.Errorf("Lookup() with another checksum should use another directory")
+	}
+}
+
+func TestDownloadCache_Prune(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		cutoff      time.Time
+		wantRemoved int
+	}{
+		{"nothing is older", now.Add(-90 * 24 * time.Hour), 0},
+		{"old downloads", now.Add(-7 * 24 * time.Hour), 1},
+		{"everything", now, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewDownloadCache(t.TempDir())
+			addCachedDownload(t, cache, "https://example.com/old.zip", 10, now.Add(-30*24*time.Hour))
+			addCachedDownload(t, cache, "https://example.com/new.zip", 20, now.Add(-time.Hour))
+
+			removed, err := cache.Prune(tt.cutoff)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if len(removed) != tt.wantRemoved {
+				t.Errorf("Prune() removed %d downloads, want %d", len(removed), tt.wantRemoved)
+			}
+
+			entries, err := cache.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(entries) != 2-tt.wantRemoved {
+				t.Errorf("List() = %d downloads after Prune(), want %d", len(entries), 2-tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestDownloadCache_List(t *testing.T) {
+	now := time.Now()
+	cache := NewDownloadCache(t.TempDir())
+	addCachedDownload(t, cache, "https://example.com/new.zip", 20, now.Add(-time.Hour))
+	addCachedDownload(t, cache, "https://example.com/old.zip", 10, now.Add(-30*24*time.Hour))
+	// A directory without metadata, e.g. from an older unosdk
+	os.MkdirAll(filepath.Join(cache.Dir(), "stray"), 0755)
+
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("List() = %d downloads, want 3", len(entries))
+	}
+	if entries[0].URL != "https://example.com/old.zip" || entries[0].Size != 10 {
+		t.Errorf("List() = %+v, want the least recently used download first", entries[0])
+	}
+
+	removed, err := cache.Clean()
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if len(removed) != 3 {
+		t.Errorf("Clean() removed %d downloads, want 3", len(removed))
+	}
+}
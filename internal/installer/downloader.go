@@ -90,13 +90,5 @@ func (d *Downloader) DownloadWithoutProgress(ctx context.Context, url, dest stri
 
 // VerifyChecksum verifies the downloaded file's checksum
 func (d *Downloader) VerifyChecksum(filePath, expectedChecksum string) error {
-	if expectedChecksum == "" {
-		// No checksum to verify
-		return nil
-	}
-
-	// TODO: Implement actual checksum verification
-	// This would use crypto/sha256 or similar
-	
-	return nil
+	return NewVerifier().VerifyChecksum(filePath, expectedChecksum)
 }
@@ -1,16 +1,33 @@
 package installer
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
+
+	"github.com/bodgit/sevenzip"
+	"github.com/ulikunitz/xz"
+)
+
+// Supported archive formats
+const (
+	formatZip   = "zip"
+	formatTar   = "tar"
+	formatTarGz = "tar.gz"
+	formatTarXz = "tar.xz"
+	format7z    = "7z"
+	formatNupkg = "nupkg"
+	formatExe   = "exe"
 )
 
+// nupkgContentDir is the directory of a NuGet package holding the payload
+const nupkgContentDir = "tools/"
+
 // Extractor handles archive extraction
 type Extractor struct{}
 
@@ -21,60 +38,48 @@ func NewExtractor() *Extractor {
 
 // Extract extracts an archive to the destination directory
 func (e *Extractor) Extract(archivePath, destPath string) error {
-	ext := strings.ToLower(filepath.Ext(archivePath))
-	
-	switch ext {
-	case ".zip":
+	format := archiveFormat(archivePath)
+
+	switch format {
+	case formatZip:
 		return e.extractZip(archivePath, destPath)
-	case ".tar", ".gz", ".tgz":
-		return e.extractTar(archivePath, destPath)
-	case ".exe":
-		// For Python .exe installers, run silent installation
-		if strings.Contains(strings.ToLower(archivePath), "python") {
-			return e.installPythonExe(archivePath, destPath)
-		}
-		// For other .exe files, just copy them
+	case formatTar, formatTarGz, formatTarXz:
+		return e.extractTar(archivePath, destPath, format)
+	case format7z:
+		return e.extract7z(archivePath, destPath)
+	case formatNupkg:
+		return e.extractNupkg(archivePath, destPath)
+	case formatExe:
+		// Executables are installed as they are
 		return e.copyFile(archivePath, filepath.Join(destPath, filepath.Base(archivePath)))
 	default:
-		return fmt.Errorf("unsupported archive format: %s", ext)
-	}
-}
-
-// installPythonExe runs Python installer with silent flags
-func (e *Extractor) installPythonExe(exePath, destPath string) error {
-	// Python installer arguments for silent installation
-	// /quiet - silent mode
-	// TargetDir - specify installation directory
-	// PrependPath=0 - don't modify PATH (we'll handle it ourselves)
-	// Include_test=0 - don't include tests
-	// SimpleInstall=1 - simple installation
-	// SimpleInstallDescription - suppress UI
-	
-	cmd := exec.Command(exePath,
-		"/quiet",
-		fmt.Sprintf("TargetDir=%s", destPath),
-		"PrependPath=0",
-		"Include_test=0",
-		"SimpleInstall=1",
-		"SimpleInstallDescription=Installing Python...",
-	)
-	
-	// Capture output for debugging
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to install Python: %w\nOutput: %s", err, string(output))
-	}
-	
-	// Wait a bit for installer to complete file operations
-	time.Sleep(2 * time.Second)
-	
-	// Verify installation
-	pythonExe := filepath.Join(destPath, "python.exe")
-	if _, err := os.Stat(pythonExe); os.IsNotExist(err) {
-		return fmt.Errorf("python.exe not found after installation at: %s", pythonExe)
-	}
-	
-	return nil
+		return fmt.Errorf("unsupported archive format: %s", filepath.Ext(archivePath))
+	}
+}
+
+// archiveFormat determines the archive format from the full file name so
+// that multi-part extensions like ".tar.gz" aren't routed by their last part
+func archiveFormat(archivePath string) string {
+	name := strings.ToLower(filepath.Base(archivePath))
+
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGz
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return formatTarXz
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	case strings.HasSuffix(name, ".zip"):
+		return formatZip
+	case strings.HasSuffix(name, ".7z"):
+		return format7z
+	case strings.HasSuffix(name, ".nupkg"):
+		return formatNupkg
+	case strings.HasSuffix(name, ".exe"):
+		return formatExe
+	default:
+		return ""
+	}
 }
 
 // extractZip extracts a ZIP archive
@@ -86,7 +91,7 @@ func (e *Extractor) extractZip(archivePath, destPath string) error {
 	defer r.Close()
 
 	for _, f := range r.File {
-		if err := e.extractZipFile(f, destPath); err != nil {
+		if err := e.extractZipFile(f, f.Name, destPath); err != nil {
 			return err
 		}
 	}
@@ -94,27 +99,178 @@ func (e *Extractor) extractZip(archivePath, destPath string) error {
 	return nil
 }
 
-// extractZipFile extracts a single file from a ZIP archive
-func (e *Extractor) extractZipFile(f *zip.File, destPath string) error {
+// extractNupkg extracts the payload of a NuGet package (a zip archive whose
+// content lives below tools/) without the package metadata
+func (e *Extractor) extractNupkg(archivePath, destPath string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open nupkg: %w", err)
+	}
+	defer r.Close()
+
+	extracted := false
+	for _, f := range r.File {
+		name := strings.ReplaceAll(f.Name, `\`, "/")
+		if !strings.HasPrefix(name, nupkgContentDir) || name == nupkgContentDir {
+			continue
+		}
+		if err := e.extractZipFile(f, strings.TrimPrefix(name, nupkgContentDir), destPath); err != nil {
+			return err
+		}
+		extracted = true
+	}
+
+	if !extracted {
+		return fmt.Errorf("nupkg has no %s directory: %s", nupkgContentDir, filepath.Base(archivePath))
+	}
+	return nil
+}
+
+// extractZipFile extracts a single file from a ZIP archive as name
+func (e *Extractor) extractZipFile(f *zip.File, name, destPath string) error {
 	// Clean the file path to prevent zip slip
-	fpath := filepath.Join(destPath, f.Name)
-	if !strings.HasPrefix(fpath, filepath.Clean(destPath)+string(os.PathSeparator)) {
-		return fmt.Errorf("illegal file path: %s", f.Name)
+	fpath, err := securePath(destPath, name)
+	if err != nil {
+		return err
 	}
 
 	if f.FileInfo().IsDir() {
 		return os.MkdirAll(fpath, os.ModePerm)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+	rc, err := f.Open()
+	if err != nil {
 		return err
 	}
+	defer rc.Close()
+
+	if f.Mode()&os.ModeSymlink != 0 {
+		return e.writeSymlink(rc, fpath, destPath)
+	}
+
+	return e.writeFile(rc, fpath, f.Mode())
+}
+
+// extractTar extracts a TAR archive, optionally gzip or xz compressed
+func (e *Extractor) extractTar(archivePath, destPath, format string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	switch format {
+	case formatTarGz:
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	case formatTarXz:
+		xr, err := xz.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to open xz stream: %w", err)
+		}
+		r = xr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar: %w", err)
+		}
 
-	outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+		if err := e.extractTarEntry(tr, header, destPath); err != nil {
+			return err
+		}
+	}
+}
+
+// extractTarEntry extracts a single entry from a TAR archive
+func (e *Extractor) extractTarEntry(tr *tar.Reader, header *tar.Header, destPath string) error {
+	fpath, err := securePath(destPath, header.Name)
 	if err != nil {
 		return err
 	}
-	defer outFile.Close()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(fpath, header.FileInfo().Mode().Perm()|0700)
+
+	case tar.TypeReg:
+		return e.writeFile(tr, fpath, header.FileInfo().Mode())
+
+	case tar.TypeSymlink:
+		return e.createSymlink(header.Linkname, fpath, destPath)
+
+	case tar.TypeLink:
+		// Hard link targets are archive paths, relative to the archive root.
+		// os.Link and the copy fallback follow symlinks, so the target is
+		// checked where it actually is.
+		target, err := securePath(destPath, header.Linkname)
+		if err != nil {
+			return err
+		}
+		if target, err = filepath.EvalSymlinks(target); err != nil {
+			return err
+		}
+		if !isWithinResolved(destPath, target) {
+			return fmt.Errorf("illegal hard link target: %s -> %s", header.Name, header.Linkname)
+		}
+		if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+			return err
+		}
+		os.Remove(fpath)
+		if err := os.Link(target, fpath); err != nil {
+			// Fall back to a copy on file systems without hard link support
+			return e.copyFile(target, fpath)
+		}
+		return nil
+
+	case tar.TypeXGlobalHeader, tar.TypeXHeader:
+		// PAX metadata is consumed by archive/tar itself
+		return nil
+
+	default:
+		// Device nodes, FIFOs etc. have no place in an SDK archive
+		return nil
+	}
+}
+
+// extract7z extracts a 7-Zip archive
+func (e *Extractor) extract7z(archivePath, destPath string) error {
+	r, err := sevenzip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open 7z: %w", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if err := e.extract7zFile(f, destPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extract7zFile extracts a single file from a 7-Zip archive
+func (e *Extractor) extract7zFile(f *sevenzip.File, destPath string) error {
+	fpath, err := securePath(destPath, f.Name)
+	if err != nil {
+		return err
+	}
+
+	info := f.FileInfo()
+	if info.IsDir() {
+		return os.MkdirAll(fpath, os.ModePerm)
+	}
 
 	rc, err := f.Open()
 	if err != nil {
@@ -122,15 +278,147 @@ func (e *Extractor) extractZipFile(f *zip.File, destPath string) error {
 	}
 	defer rc.Close()
 
-	_, err = io.Copy(outFile, rc)
-	return err
+	if info.Mode()&os.ModeSymlink != 0 {
+		return e.writeSymlink(rc, fpath, destPath)
+	}
+
+	return e.writeFile(rc, fpath, info.Mode())
+}
+
+// writeFile writes the content of r to path, creating parent directories
+func (e *Extractor) writeFile(r io.Reader, path string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	// A symlink left by an earlier entry is replaced, not written through
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	// Archives created on Windows often carry no permission bits
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = 0644
+	}
+
+	outFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, r); err != nil {
+		return err
+	}
+
+	// OpenFile applies the umask, so set the archived mode explicitly
+	return os.Chmod(path, perm)
+}
+
+// writeSymlink creates a symlink whose target is stored as the entry content
+// (the convention used by zip and 7z archives)
+func (e *Extractor) writeSymlink(r io.Reader, path, destPath string) error {
+	target, err := io.ReadAll(io.LimitReader(r, 4096))
+	if err != nil {
+		return err
+	}
+	return e.createSymlink(string(target), path, destPath)
+}
+
+// createSymlink creates a symlink at path pointing to target, refusing
+// targets that would resolve outside of destPath
+func (e *Extractor) createSymlink(target, path, destPath string) error {
+	if filepath.IsAbs(target) || strings.HasPrefix(target, "/") || strings.HasPrefix(target, `\`) {
+		return fmt.Errorf("illegal symlink target: %s -> %s", path, target)
+	}
+
+	resolved := filepath.Join(filepath.Dir(path), filepath.FromSlash(target))
+	if !isWithin(destPath, resolved) {
+		return fmt.Errorf("illegal symlink target: %s -> %s", path, target)
+	}
+
+	// The link is created in the directory its parent resolves to, which
+	// differs from the lexical one when earlier entries created symlinks
+	parent, err := resolveExisting(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if !isWithinResolved(destPath, filepath.Join(parent, filepath.FromSlash(target))) {
+		return fmt.Errorf("illegal symlink target: %s -> %s", path, target)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	os.Remove(path)
+
+	return os.Symlink(target, path)
+}
+
+// securePath joins an archive entry name onto destPath, rejecting entries
+// that would escape the destination directory (zip slip). Besides the
+// lexical check, the existing parent directories are resolved: a chain of
+// symlinks from earlier entries (d/l1 -> .., d/l1/l2 -> ..) must not lead
+// the entry outside of destPath.
+func securePath(destPath, name string) (string, error) {
+	fpath := filepath.Join(destPath, filepath.FromSlash(name))
+	if !isWithin(destPath, fpath) {
+		return "", fmt.Errorf("illegal file path: %s", name)
+	}
+
+	parent, err := resolveExisting(filepath.Dir(fpath))
+	if err != nil {
+		return "", err
+	}
+	if !isWithinResolved(destPath, parent) {
+		return "", fmt.Errorf("illegal file path: %s", name)
+	}
+	return fpath, nil
+}
+
+// resolveExisting resolves the symlinks of the longest existing prefix of
+// path and appends the part that does not exist yet
+func resolveExisting(path string) (string, error) {
+	var missing []string
+	for dir := path; ; {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, missing...)...), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		// A dangling symlink exists but leads nowhere yet
+		if _, err := os.Lstat(dir); err == nil {
+			return "", fmt.Errorf("illegal path through dangling symlink: %s", dir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return path, nil
+		}
+		missing = append([]string{filepath.Base(dir)}, missing...)
+		dir = parent
+	}
+}
+
+// isWithinResolved reports whether a resolved path lies within destPath,
+// comparing against destPath with its own symlinks resolved
+func isWithinResolved(destPath, path string) bool {
+	if resolved, err := filepath.EvalSymlinks(destPath); err == nil {
+		destPath = resolved
+	}
+	return isWithin(destPath, path)
 }
 
-// extractTar extracts a TAR archive (including .tar.gz, .tgz)
-func (e *Extractor) extractTar(archivePath, destPath string) error {
-	// This is a placeholder - in production, you'd use archive/tar
-	// and compress/gzip packages
-	return fmt.Errorf("TAR extraction not yet implemented")
+// isWithin reports whether path is destPath or located below it
+func isWithin(destPath, path string) bool {
+	destPath = filepath.Clean(destPath)
+	path = filepath.Clean(path)
+	return path == destPath || strings.HasPrefix(path, destPath+string(os.PathSeparator))
 }
 
 // copyFile copies a file from src to dst
@@ -0,0 +1,333 @@
+package installer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+// tarEntry describes an entry written by writeTestTar
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+	mode     int64
+}
+
+// sdkTarEntries mimics the layout of a typical Linux SDK tarball
+var sdkTarEntries = []tarEntry{
+	{name: "jdk/", typeflag: tar.TypeDir, mode: 0755},
+	{name: "jdk/bin/", typeflag: tar.TypeDir, mode: 0755},
+	{name: "jdk/bin/java", typeflag: tar.TypeReg, body: "#!/bin/sh\n", mode: 0755},
+	{name: "jdk/release", typeflag: tar.TypeReg, body: "JAVA_VERSION=21", mode: 0644},
+	{name: "jdk/bin/java-link", typeflag: tar.TypeSymlink, linkname: "java", mode: 0777},
+	{name: "jdk/release-hardlink", typeflag: tar.TypeLink, linkname: "jdk/release", mode: 0644},
+}
+
+func writeTestTar(t *testing.T, w io.Writer, entries []tarEntry) {
+	t.Helper()
+	tw := tar.NewWriter(w)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     entry.mode,
+			Size:     int64(len(entry.body)),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if entry.body != "" {
+			if _, err := tw.Write([]byte(entry.body)); err != nil {
+				t.Fatalf("failed to write tar body: %v", err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+}
+
+func createTestArchive(t *testing.T, name string, entries []tarEntry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	switch archiveFormat(name) {
+	case formatTarGz:
+		gz := gzip.NewWriter(file)
+		writeTestTar(t, gz, entries)
+		gz.Close()
+	case formatTarXz:
+		xw, err := xz.NewWriter(file)
+		if err != nil {
+			t.Fatalf("failed to create xz writer: %v", err)
+		}
+		writeTestTar(t, xw, entries)
+		xw.Close()
+	default:
+		writeTestTar(t, file, entries)
+	}
+
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestArchiveFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"OpenJDK21U-jdk_x64_windows_hotspot_21.0.10_7.zip", formatZip},
+		{"OpenJDK21U-jdk_x64_linux_hotspot_21.0.10_7.tar.gz", formatTarGz},
+		{"archive.TGZ", formatTarGz},
+		{"node-v22.1.0-linux-x64.tar.xz", formatTarXz},
+		{"archive.tar", formatTar},
+		{"winlibs-x86_64-posix-seh-gcc-15.2.0.7z", format7z},
+		{"python.3.14.3.nupkg", formatNupkg},
+		{"python-3.12.1-amd64.exe", formatExe},
+		{"archive.rar", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := archiveFormat(tt.path); got != tt.want {
+				t.Errorf("archiveFormat(%v) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_ExtractTar(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires elevated privileges on Windows")
+	}
+
+	for _, name := range []string{"sdk.tar", "sdk.tar.gz", "sdk.tgz", "sdk.tar.xz"} {
+		t.Run(name, func(t *testing.T) {
+			archive := createTestArchive(t, name, sdkTarEntries)
+			dest := t.TempDir()
+
+			if err := NewExtractor().Extract(archive, dest); err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+
+			java := filepath.Join(dest, "jdk", "bin", "java")
+			info, err := os.Stat(java)
+			if err != nil {
+				t.Fatalf("java not extracted: %v", err)
+			}
+			if info.Mode().Perm() != 0755 {
+				t.Errorf("java mode = %v, want %v", info.Mode().Perm(), os.FileMode(0755))
+			}
+
+			link := filepath.Join(dest, "jdk", "bin", "java-link")
+			target, err := os.Readlink(link)
+			if err != nil {
+				t.Fatalf("symlink not extracted: %v", err)
+			}
+			if target != "java" {
+				t.Errorf("symlink target = %v, want %v", target, "java")
+			}
+
+			if got := readFile(t, filepath.Join(dest, "jdk", "release-hardlink")); got != "JAVA_VERSION=21" {
+				t.Errorf("hard link content = %q, want %q", got, "JAVA_VERSION=21")
+			}
+		})
+	}
+}
+
+func TestExtractor_ExtractTar_PathTraversal(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name:    "file outside destination",
+			entries: []tarEntry{{name: "../evil", typeflag: tar.TypeReg, body: "x", mode: 0644}},
+		},
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{{name: "link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd", mode: 0777}},
+		},
+		{
+			name:    "relative symlink escaping destination",
+			entries: []tarEntry{{name: "dir/link", typeflag: tar.TypeSymlink, linkname: "../../outside", mode: 0777}},
+		},
+		{
+			name:    "hard link outside destination",
+			entries: []tarEntry{{name: "link", typeflag: tar.TypeLink, linkname: "../outside", mode: 0644}},
+		},
+		{
+			// Each link stays inside on its own, but l2 is created through l1
+			name: "chain of symlinks",
+			entries: []tarEntry{
+				{name: "d1/", typeflag: tar.TypeDir, mode: 0755},
+				{name: "d1/l1", typeflag: tar.TypeSymlink, linkname: "..", mode: 0777},
+				{name: "d1/l1/l2", typeflag: tar.TypeSymlink, linkname: "..", mode: 0777},
+				{name: "d1/l1/l2/evil", typeflag: tar.TypeReg, body: "x", mode: 0644},
+			},
+		},
+		{
+			// x -> s/.. is inside until s becomes a link to the destination
+			name: "file through a symlink retargeted by a later entry",
+			entries: []tarEntry{
+				{name: "x", typeflag: tar.TypeSymlink, linkname: "s/..", mode: 0777},
+				{name: "s", typeflag: tar.TypeSymlink, linkname: ".", mode: 0777},
+				{name: "x/evil", typeflag: tar.TypeReg, body: "x", mode: 0644},
+			},
+		},
+		{
+			name: "hard link through a symlink",
+			entries: []tarEntry{
+				{name: "x", typeflag: tar.TypeSymlink, linkname: "s/..", mode: 0777},
+				{name: "s", typeflag: tar.TypeSymlink, linkname: ".", mode: 0777},
+				{name: "evil", typeflag: tar.TypeLink, linkname: "x/outside", mode: 0644},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			os.WriteFile(filepath.Join(parent, "outside"), []byte("secret"), 0644)
+			destPath := filepath.Join(parent, "dest")
+
+			archive := createTestArchive(t, "evil.tar.gz", tt.entries)
+			if err := NewExtractor().Extract(archive, destPath); err == nil {
+				t.Error("Extract() should reject entries escaping the destination")
+			}
+			if _, err := os.Lstat(filepath.Join(parent, "evil")); !os.IsNotExist(err) {
+				t.Errorf("Extract() wrote outside the destination: %v", err)
+			}
+		})
+	}
+}
+
+func TestExtractor_ExtractTar_InternalSymlinkDir(t *testing.T) {
+	entries := []tarEntry{
+		{name: "sdk/lib64/", typeflag: tar.TypeDir, mode: 0755},
+		{name: "sdk/lib", typeflag: tar.TypeSymlink, linkname: "lib64", mode: 0777},
+		{name: "sdk/lib/libjvm.so", typeflag: tar.TypeReg, body: "elf", mode: 0644},
+		{name: "sdk/libjvm-link.so", typeflag: tar.TypeLink, linkname: "sdk/lib/libjvm.so", mode: 0644},
+	}
+	archive := createTestArchive(t, "sdk.tar.gz", entries)
+	destPath := t.TempDir()
+
+	if err := NewExtractor().Extract(archive, destPath); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if got := readFile(t, filepath.Join(destPath, "sdk", "lib64", "libjvm.so")); got != "elf" {
+		t.Errorf("file written through an internal symlink = %q, want %q", got, "elf")
+	}
+	if got := readFile(t, filepath.Join(destPath, "sdk", "libjvm-link.so")); got != "elf" {
+		t.Errorf("hard link through an internal symlink = %q, want %q", got, "elf")
+	}
+}
+
+func TestExtractor_ExtractZip(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "sdk.zip")
+	file, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+	zw := zip.NewWriter(file)
+
+	header := &zip.FileHeader{Name: "sdk/bin/tool", Method: zip.Deflate}
+	header.SetMode(0755)
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		t.Fatalf("failed to add zip entry: %v", err)
+	}
+	w.Write([]byte("tool"))
+	zw.Close()
+	file.Close()
+
+	dest := t.TempDir()
+	if err := NewExtractor().Extract(archive, dest); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	tool := filepath.Join(dest, "sdk", "bin", "tool")
+	if got := readFile(t, tool); got != "tool" {
+		t.Errorf("tool content = %q, want %q", got, "tool")
+	}
+	if runtime.GOOS != "windows" {
+		if info, _ := os.Stat(tool); info.Mode().Perm() != 0755 {
+			t.Errorf("tool mode = %v, want %v", info.Mode().Perm(), os.FileMode(0755))
+		}
+	}
+}
+
+func TestExtractor_ExtractNupkg(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "python.3.14.3.nupkg")
+	file, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("failed to create nupkg: %v", err)
+	}
+	zw := zip.NewWriter(file)
+	for _, name := range []string{"python.nuspec", "[Content_Types].xml", "tools/", "tools/python.exe", "tools/Lib/os.py"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add nupkg entry: %v", err)
+		}
+		w.Write([]byte(name))
+	}
+	zw.Close()
+	file.Close()
+
+	dest := t.TempDir()
+	if err := NewExtractor().Extract(archive, dest); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if got := readFile(t, filepath.Join(dest, "python.exe")); got != "tools/python.exe" {
+		t.Errorf("python.exe content = %q, want %q", got, "tools/python.exe")
+	}
+	if got := readFile(t, filepath.Join(dest, "Lib", "os.py")); got != "tools/Lib/os.py" {
+		t.Errorf("os.py content = %q, want %q", got, "tools/Lib/os.py")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "python.nuspec")); !os.IsNotExist(err) {
+		t.Error("package metadata should not be extracted")
+	}
+}
+
+func TestExtractor_Extract7z(t *testing.T) {
+	dest := t.TempDir()
+	if err := NewExtractor().Extract(filepath.Join("testdata", "simple.7z"), dest); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	for _, name := range []string{"foo", "bar"} {
+		if got := readFile(t, filepath.Join(dest, name)); got != name+"\n" {
+			t.Errorf("%s content = %q, want %q", name, got, name+"\n")
+		}
+	}
+}
+
+func TestExtractor_UnsupportedFormat(t *testing.T) {
+	if err := NewExtractor().Extract("archive.rar", t.TempDir()); err == nil {
+		t.Error("Extract() should fail for unsupported formats")
+	}
+}
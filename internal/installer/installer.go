@@ -2,11 +2,18 @@ package installer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
+	"github.com/cavaliergopher/grab/v3"
 	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/internal/shim"
 	"github.com/javaquery/unosdk/pkg/models"
 	"github.com/javaquery/unosdk/pkg/utils"
 	"go.uber.org/zap"
@@ -17,6 +24,8 @@ type Installer struct {
 	registry   *providers.Registry
 	downloader *Downloader
 	extractor  *Extractor
+	verifier   *Verifier
+	cache      *DownloadCache
 	logger     *zap.Logger
 }
 
@@ -26,6 +35,8 @@ func NewInstaller(registry *providers.Registry) *Installer {
 		registry:   registry,
 		downloader: NewDownloader(),
 		extractor:  NewExtractor(),
+		verifier:   NewVerifier(),
+		cache:      NewDefaultDownloadCache(),
 		logger:     utils.NewLogger(),
 	}
 }
@@ -49,76 +60,151 @@ func (i *Installer) Install(ctx context.Context, sdkType models.SDKType, provide
 		return nil, fmt.Errorf("invalid version: %w", err)
 	}
 
-	// Handle "latest" version
-	if version == "latest" {
-		latest, err := provider.GetLatestVersion(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get latest version: %w", err)
-		}
-		version = latest
+	// Handle "latest" and provider aliases such as "lts"
+	version, err := providers.ResolveVersion(ctx, provider, version)
+	if err != nil {
+		return nil, err
+	}
+	// The version names the install directory, it must not lead out of it
+	if err := models.ValidateVersionName(version); err != nil {
+		return nil, err
 	}
 
 	// Get download URL
-	downloadURL, err := provider.GetDownloadURL(version, arch)
+	downloadURL, err := providers.DownloadURL(ctx, provider, version, arch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get download URL: %w", err)
 	}
 
+	// Resolve the vendor checksum before downloading anything
+	checksum, err := provider.GetChecksum(ctx, version, arch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checksum: %w", err)
+	}
+	if checksum != "" {
+		if checksum, err = FormatChecksum(checksum); err != nil {
+			return nil, fmt.Errorf("invalid checksum from provider: %w", err)
+		}
+	}
+
 	// Get install path
 	installPath := provider.GetDefaultInstallPath(version)
 
+	// Staging directories left behind by interrupted installs are never complete
+	i.removeStaging(installPath)
+
 	// Check if already installed by looking for actual content
-	if _, err := os.Stat(installPath); err == nil {
-		// Check if directory has content (not just an empty dir left from previous uninstall)
-		entries, readErr := os.ReadDir(installPath)
-		if readErr == nil && len(entries) > 0 {
+	if entries, err := os.ReadDir(installPath); err == nil && len(entries) > 0 {
+		// Find the actual install path (might be a subdirectory)
+		actualPath, _ := i.findActualInstallPath(installPath)
+		invalid := validateInstall(provider, actualPath)
+		if invalid == nil {
 			i.logger.Warn("SDK already installed at path", zap.String("path", installPath))
-			// Find the actual install path (might be a subdirectory)
-			actualPath, _ := i.findActualInstallPath(installPath)
 			return &models.SDK{
 				Type:        sdkType,
 				Provider:    providerName,
 				Version:     version,
 				InstallPath: actualPath,
+				Source:      models.SourceExisting,
+				Arch:        arch,
+				Size:        dirSize(actualPath),
 				Installed:   true,
 			}, nil
 		}
-		// If directory is empty, remove it and continue with installation
-		os.RemoveAll(installPath)
+		// Installs are staged, so this tree was not created by unosdk as is.
+		// It is left for the user to inspect instead of being deleted.
+		return nil, fmt.Errorf("%s exists but is not a complete installation (%v), remove it and try again", installPath, invalid)
+	}
+	// Remove an empty directory and continue with installation
+	if err := os.Remove(installPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove empty install directory: %w", err)
 	}
 
-	// Create temporary directory for download
-	tempDir, err := os.MkdirTemp("", "unosdk-*")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	// Download into the cache, resuming a partial download and reusing an
+	// archive verified by an earlier install
+	download := i.cache.Lookup(downloadURL, checksum)
+	downloadPath := download.Path()
+	if download.Reusable() {
+		i.logger.Info("Using cached download", zap.String("path", downloadPath))
+	} else {
+		// Without a checksum a complete download cannot be trusted again
+		if download.Complete {
+			os.Remove(downloadPath)
+			download.Complete = false
+		}
+		if err := i.cache.Save(download); err != nil {
+			return nil, err
+		}
+
+		i.logger.Info("Downloading SDK", zap.String("url", downloadURL))
+		if err := i.downloader.Download(ctx, downloadURL, downloadPath); err != nil {
+			// A partial file larger than the archive cannot be resumed
+			if errors.Is(err, grab.ErrBadLength) {
+				i.cache.Remove(download)
+			}
+			return nil, fmt.Errorf("download failed: %w", err)
+		}
+		download.Complete = true
 	}
-	defer os.RemoveAll(tempDir)
 
-	// Download
-	i.logger.Info("Downloading SDK", zap.String("url", downloadURL))
-	downloadPath := filepath.Join(tempDir, filepath.Base(downloadURL))
-	
-	if err := i.downloader.Download(ctx, downloadURL, downloadPath); err != nil {
-		return nil, fmt.Errorf("download failed: %w", err)
+	// Verify
+	if checksum == "" {
+		i.logger.Warn("No checksum published for download, skipping verification", zap.String("url", downloadURL))
+	} else {
+		i.logger.Info("Verifying checksum", zap.String("checksum", checksum))
+		if err := i.verifier.VerifyChecksum(downloadPath, checksum); err != nil {
+			// A corrupt archive would fail again when resumed
+			i.cache.Remove(download)
+			return nil, fmt.Errorf("verification failed: %w", err)
+		}
+		download.Verified = true
+	}
+	if err := i.cache.Save(download); err != nil {
+		i.logger.Warn("Failed to update download cache", zap.Error(err))
 	}
 
-	// Create install directory
-	if err := os.MkdirAll(installPath, 0755); err != nil {
+	// Extract into a staging directory next to the install path and move it
+	// into place once complete, so that a failed or interrupted install never
+	// leaves a partial tree behind
+	if err := os.MkdirAll(filepath.Dir(installPath), 0755); err != nil {
 		return nil, fmt.Errorf("failed to create install directory: %w", err)
 	}
+	stagingPath, err := os.MkdirTemp(filepath.Dir(installPath), stagingPrefix(installPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingPath)
+	if err := os.Chmod(stagingPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
 
 	// Extract
-	i.logger.Info("Extracting SDK", zap.String("path", installPath))
-	if err := i.extractor.Extract(downloadPath, installPath); err != nil {
+	i.logger.Info("Extracting SDK", zap.String("path", stagingPath))
+	if err := i.extractor.Extract(downloadPath, stagingPath); err != nil {
 		return nil, fmt.Errorf("extraction failed: %w", err)
 	}
 
 	// Check if extraction created a single root directory
 	// If so, update installPath to point to that directory
-	actualInstallPath, err := i.findActualInstallPath(installPath)
+	stagedHome, err := i.findActualInstallPath(stagingPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine actual install path: %w", err)
+	}
+	if err := validateInstall(provider, stagedHome); err != nil {
+		return nil, fmt.Errorf("installation is incomplete: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("installation interrupted: %w", err)
+	}
+
+	if err := os.Rename(stagingPath, installPath); err != nil {
+		return nil, fmt.Errorf("failed to move installation into place: %w", err)
+	}
+	rel, err := filepath.Rel(stagingPath, stagedHome)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine actual install path: %w", err)
 	}
+	actualInstallPath := filepath.Join(installPath, rel)
 
 	sdk := &models.SDK{
 		Type:        sdkType,
@@ -126,6 +212,10 @@ func (i *Installer) Install(ctx context.Context, sdkType models.SDKType, provide
 		Version:     version,
 		InstallPath: actualInstallPath,
 		DownloadURL: downloadURL,
+		Checksum:    checksum,
+		Source:      models.SourceDownload,
+		Arch:        arch,
+		Size:        dirSize(actualInstallPath),
 		Installed:   true,
 	}
 
@@ -133,6 +223,68 @@ func (i *Installer) Install(ctx context.Context, sdkType models.SDKType, provide
 	return sdk, nil
 }
 
+// stagingPrefix returns the name prefix of the staging directories of an
+// install path, e.g. ".21.staging-" for .../openjdk/21
+func stagingPrefix(installPath string) string {
+	return "." + filepath.Base(installPath) + ".staging-"
+}
+
+// stagingGracePeriod is how long a staging directory is assumed to belong to
+// an install still running in another process
+const stagingGracePeriod = time.Hour
+
+// IsStagingDir reports whether a directory name is that of a staging
+// directory, e.g. ".21.staging-123456"
+func IsStagingDir(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.Contains(name, ".staging-")
+}
+
+// IsActiveStaging reports whether path is a staging directory that an
+// install running in another process may still be writing to
+func IsActiveStaging(path string) bool {
+	if !IsStagingDir(filepath.Base(path)) {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) < stagingGracePeriod
+}
+
+// removeStaging deletes the staging directories left behind by interrupted
+// installs of an install path, keeping those of installs still running
+func (i *Installer) removeStaging(installPath string) {
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(installPath), stagingPrefix(installPath)+"*"))
+	for _, leftover := range leftovers {
+		if IsActiveStaging(leftover) {
+			continue
+		}
+		i.logger.Warn("Removing interrupted installation", zap.String("path", leftover))
+		os.RemoveAll(leftover)
+	}
+}
+
+// validateInstall checks that an installation provides the main executable of
+// its SDK type (java, node, mvn, ...) in one of its PATH directories
+func validateInstall(provider providers.Provider, home string) error {
+	tools := shim.Tools(provider.Type())
+	if len(tools) == 0 {
+		return nil
+	}
+
+	spec := models.DefaultEnvSpec(provider.Type(), runtime.GOOS)
+	if envProvider, ok := provider.(providers.EnvProvider); ok && !envProvider.EnvSpec().IsEmpty() {
+		spec = envProvider.EnvSpec()
+	}
+	_, dirs := spec.Expand(home)
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	if _, ok := shim.FindExecutable(dirs, tools[0]); !ok {
+		return fmt.Errorf("%s not found in %s", tools[0], strings.Join(dirs, ", "))
+	}
+	return nil
+}
+
 // findActualInstallPath checks if the extraction created a single root directory
 // and returns the path to that directory, otherwise returns the original path
 func (i *Installer) findActualInstallPath(installPath string) (string, error) {
@@ -143,13 +295,23 @@ func (i *Installer) findActualInstallPath(installPath string) (string, error) {
 
 	// If there's exactly one entry and it's a directory, use that as the install path
 	if len(entries) == 1 && entries[0].IsDir() {
-		return filepath.Join(installPath, entries[0].Name()), nil
+		return bundleHome(filepath.Join(installPath, entries[0].Name())), nil
 	}
 
 	// Otherwise, return the original path
 	return installPath, nil
 }
 
+// bundleHome returns the Contents/Home directory of macOS JDK bundles, which
+// is where JAVA_HOME has to point, or the path itself for any other layout
+func bundleHome(path string) string {
+	home := filepath.Join(path, "Contents", "Home")
+	if info, err := os.Stat(home); err == nil && info.IsDir() {
+		return home
+	}
+	return path
+}
+
 // Uninstall removes an installed SDK
 func (i *Installer) Uninstall(installPath string) error {
 	i.logger.Info("Uninstalling SDK", zap.String("path", installPath))
@@ -158,6 +320,11 @@ func (i *Installer) Uninstall(installPath string) error {
 		return fmt.Errorf("SDK not found at path: %s", installPath)
 	}
 
+	// macOS JDK bundles are registered by their Contents/Home directory
+	if filepath.Base(installPath) == "Home" && filepath.Base(filepath.Dir(installPath)) == "Contents" {
+		installPath = filepath.Dir(filepath.Dir(installPath))
+	}
+
 	// Remove the SDK directory
 	if err := os.RemoveAll(installPath); err != nil {
 		return fmt.Errorf("failed to remove SDK: %w", err)
@@ -175,3 +342,18 @@ func (i *Installer) Uninstall(installPath string) error {
 	i.logger.Info("Uninstallation completed successfully")
 	return nil
 }
+
+// dirSize returns the total size of the files below path in bytes
+func dirSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
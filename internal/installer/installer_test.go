@@ -0,0 +1,390 @@
+package installer
+
+import (
+	"archive/tar"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/providers"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// testProvider serves a single version of Java from a test server
+type testProvider struct {
+	url         string
+	checksum    string
+	installPath string
+}
+
+func (p *testProvider) Name() string        { return "test" }
+func (p *testProvider) DisplayName() string { return "Test" }
+func (p *testProvider) Type() models.SDKType {
+	return models.JavaSDK
+}
+func (p *testProvider) GetVersions(ctx context.Context) ([]string, error) {
+	return []string{"21"}, nil
+}
+func (p *testProvider) GetLatestVersion(ctx context.Context) (string, error) {
+	return "21", nil
+}
+func (p *testProvider) GetDownloadURL(version, arch string) (string, error) {
+	return p.url, nil
+}
+func (p *testProvider) GetChecksum(ctx context.Context, version, arch string) (string, error) {
+	return p.checksum, nil
+}
+func (p *testProvider) GetDefaultInstallPath(version string) string { return p.installPath }
+func (p *testProvider) Validate(version string) error               { return nil }
+
+// testServer records the Range header of each download request, empty for
+// a full download
+type testServer struct {
+	// url is the download URL of the archive
+	url string
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *testServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+// newTestInstaller returns an installer with an empty download cache whose
+// test provider downloads archive to an install path in a temporary directory
+func newTestInstaller(t *testing.T, archive, checksum string) (*Installer, string, *testServer) {
+	t.Helper()
+	recorder := &testServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			recorder.mu.Lock()
+			recorder.ranges = append(recorder.ranges, r.Header.Get("Range"))
+			recorder.mu.Unlock()
+		}
+		http.ServeFile(w, r, archive)
+	}))
+	t.Cleanup(server.Close)
+	recorder.url = server.URL + "/jdk.tar.gz"
+
+	installPath := filepath.Join(t.TempDir(), "java", "test", "21")
+	registry := providers.NewRegistry()
+	registry.Register(&testProvider{url: recorder.url, checksum: checksum, installPath: installPath})
+	inst := NewInstaller(registry)
+	inst.cache = NewDownloadCache(t.TempDir())
+	return inst, installPath, recorder
+}
+
+// javaEntries returns the entries of a JDK archive, with or without java
+func javaEntries(withJava bool) []tarEntry {
+	entries := []tarEntry{
+		{name: "jdk/", typeflag: tar.TypeDir, mode: 0755},
+		{name: "jdk/bin/", typeflag: tar.TypeDir, mode: 0755},
+		{name: "jdk/release", typeflag: tar.TypeReg, body: "JAVA_VERSION=21", mode: 0644},
+	}
+	if withJava {
+		name := "jdk/bin/java"
+		if runtime.GOOS == "windows" {
+			name += ".exe"
+		}
+		entries = append(entries, tarEntry{name: name, typeflag: tar.TypeReg, body: "#!/bin/sh\n", mode: 0755})
+	}
+	return entries
+}
+
+// stagingLeftovers returns the staging directories next to an install path
+func stagingLeftovers(t *testing.T, installPath string) []string {
+	t.Helper()
+	leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(installPath), stagingPrefix(installPath)+"*"))
+	if err != nil {
+		t.Fatalf("failed to list staging directories: %v", err)
+	}
+	return leftovers
+}
+
+func TestInstaller_Install(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive func(t *testing.T) string
+		wantErr bool
+	}{
+		{"complete archive", func(t *testing.T) string {
+			return createTestArchive(t, "jdk.tar.gz", javaEntries(true))
+		}, false},
+		{"archive without java", func(t *testing.T) string {
+			return createTestArchive(t, "jdk.tar.gz", javaEntries(false))
+		}, true},
+		{"corrupt archive", func(t *testing.T) string {
+			path := filepath.Join(t.TempDir(), "jdk.tar.gz")
+			os.WriteFile(path, []byte("not an archive"), 0644)
+			return path
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst, installPath, _ := newTestInstaller(t, tt.archive(t), "")
+
+			sdk, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if leftovers := stagingLeftovers(t, installPath); len(leftovers) > 0 {
+				t.Errorf("Install() left staging directories %v", leftovers)
+			}
+
+			if tt.wantErr {
+				if _, err := os.Stat(installPath); !os.IsNotExist(err) {
+					t.Errorf("failed Install() should not create %s", installPath)
+				}
+				return
+			}
+			if want := filepath.Join(installPath, "jdk"); sdk.InstallPath != want {
+				t.Errorf("Install() path = %v, want %v", sdk.InstallPath, want)
+			}
+			if sdk.Source != models.SourceDownload {
+				t.Errorf("Install() source = %v, want %v", sdk.Source, models.SourceDownload)
+			}
+		})
+	}
+}
+
+func TestInstaller_Install_Interrupted(t *testing.T) {
+	inst, installPath, _ := newTestInstaller(t, createTestArchive(t, "jdk.tar.gz", javaEntries(true)), "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := inst.Install(ctx, models.JavaSDK, "test", "21", runtime.GOARCH); err == nil {
+		t.Fatal("Install() should fail once interrupted")
+	}
+	if _, err := os.Stat(installPath); !os.IsNotExist(err) {
+		t.Errorf("interrupted Install() should not create %s", installPath)
+	}
+	if leftovers := stagingLeftovers(t, installPath); len(leftovers) > 0 {
+		t.Errorf("interrupted Install() left staging directories %v", leftovers)
+	}
+}
+
+func TestInstaller_Install_Leftovers(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    bool
+		stagingAge  time.Duration
+		wantErr     bool
+		wantSource  string
+		wantStaging bool
+	}{
+		{name: "partial tree is kept and reported", existing: false, stagingAge: 2 * time.Hour, wantErr: true},
+		{name: "complete tree is kept", existing: true, stagingAge: 2 * time.Hour, wantSource: models.SourceExisting},
+		{name: "staging of a running install is kept", existing: true, stagingAge: time.Minute, wantSource: models.SourceExisting, wantStaging: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst, installPath, _ := newTestInstaller(t, createTestArchive(t, "jdk.tar.gz", javaEntries(true)), "")
+
+			// A tree and a staging directory left behind by earlier installs
+			var files []string
+			for _, entry := range javaEntries(tt.existing) {
+				if entry.typeflag == tar.TypeReg {
+					files = append(files, entry.name)
+				}
+			}
+			for _, file := range files {
+				path := filepath.Join(installPath, filepath.FromSlash(file))
+				os.MkdirAll(filepath.Dir(path), 0755)
+				os.WriteFile(path, nil, 0755)
+			}
+			staging := filepath.Join(filepath.Dir(installPath), stagingPrefix(installPath)+"123")
+			os.MkdirAll(filepath.Join(staging, "jdk"), 0755)
+			modified := time.Now().Add(-tt.stagingAge)
+			os.Chtimes(staging, modified, modified)
+
+			sdk, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Install() should fail on an incomplete tree")
+				}
+				for _, file := range files {
+					if _, err := os.Stat(filepath.Join(installPath, filepath.FromSlash(file))); err != nil {
+						t.Errorf("Install() removed %s of the existing tree", file)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			if sdk.Source != tt.wantSource {
+				t.Errorf("Install() source = %v, want %v", sdk.Source, tt.wantSource)
+			}
+			if _, err := os.Stat(filepath.Join(sdk.InstallPath, "bin")); err != nil {
+				t.Errorf("Install() path %s has no bin directory", sdk.InstallPath)
+			}
+			if leftovers := stagingLeftovers(t, installPath); (len(leftovers) > 0) != tt.wantStaging {
+				t.Errorf("Install() staging directories = %v, want kept: %v", leftovers, tt.wantStaging)
+			}
+		})
+	}
+}
+
+func TestInstaller_Install_InvalidVersion(t *testing.T) {
+	inst, installPath, _ := newTestInstaller(t, createTestArchive(t, "jdk.tar.gz", javaEntries(true)), "")
+
+	// A directory outside the provider root that the version would point at
+	outside := filepath.Join(filepath.Dir(filepath.Dir(installPath)), "x")
+	os.MkdirAll(outside, 0755)
+	os.WriteFile(filepath.Join(outside, "keep"), []byte("data"), 0644)
+
+	if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "../x", runtime.GOARCH); err == nil {
+		t.Fatal("Install() should reject a version with a path separator")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "keep")); err != nil {
+		t.Errorf("Install() touched %s: %v", outside, err)
+	}
+}
+
+func TestInstaller_FindActualInstallPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"single root directory", []string{"jdk-21.0.10+7/bin/java"}, "jdk-21.0.10+7"},
+		{"macOS bundle", []string{"jdk-21.0.10+7/Contents/Home/bin/java", "jdk-21.0.10+7/Contents/Info.plist"}, "jdk-21.0.10+7/Contents/Home"},
+		{"flat layout", []string{"bin/go", "VERSION"}, ""},
+	}
+
+	inst := NewInstaller(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(dir, filepath.FromSlash(file))
+				os.MkdirAll(filepath.Dir(path), 0755)
+				os.WriteFile(path, nil, 0644)
+			}
+
+			got, err := inst.findActualInstallPath(dir)
+			if err != nil {
+				t.Fatalf("findActualInstallPath() error = %v", err)
+			}
+			if want := filepath.Join(dir, filepath.FromSlash(tt.want)); got != want {
+				t.Errorf("findActualInstallPath() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestInstaller_Uninstall_Bundle(t *testing.T) {
+	versionDir := filepath.Join(t.TempDir(), "21")
+	home := filepath.Join(versionDir, "jdk-21.0.10+7", "Contents", "Home")
+	os.MkdirAll(filepath.Join(home, "bin"), 0755)
+
+	if err := NewInstaller(nil).Uninstall(home); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if _, err := os.Stat(versionDir); !os.IsNotExist(err) {
+		t.Errorf("Uninstall() should remove the whole bundle and its version directory")
+	}
+}
+
+// testChecksum returns the SHA-256 of a file as formatted by the installer
+func testChecksum(t *testing.T, path string) string {
+	t.Helper()
+	checksum, err := NewVerifier().Checksum(path, SHA256)
+	if err == nil {
+		checksum, err = FormatChecksum(checksum)
+	}
+	if err != nil {
+		t.Fatalf("failed to checksum %s: %v", path, err)
+	}
+	return checksum
+}
+
+func TestInstaller_Install_Cache(t *testing.T) {
+	archive := createTestArchive(t, "jdk.tar.gz", javaEntries(true))
+
+	tests := []struct {
+		name     string
+		checksum bool
+		// partial writes the first half of the archive to the cache
+		partial     bool
+		wantGets    int
+		wantResumed bool
+	}{
+		{"verified archive is reused", true, false, 1, false},
+		{"unverified archive is downloaded again", false, false, 2, false},
+		{"partial archive is resumed", true, true, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checksum := ""
+			if tt.checksum {
+				checksum = testChecksum(t, archive)
+			}
+			inst, installPath, server := newTestInstaller(t, archive, checksum)
+
+			if tt.partial {
+				data, _ := os.ReadFile(archive)
+				download := inst.cache.Lookup(server.url, checksum)
+				inst.cache.Save(download)
+				os.WriteFile(download.Path(), data[:len(data)/2], 0644)
+			} else {
+				if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH); err != nil {
+					t.Fatalf("first Install() error = %v", err)
+				}
+				os.RemoveAll(installPath)
+			}
+
+			if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH); err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+
+			requests := server.requests()
+			if len(requests) != tt.wantGets {
+				t.Errorf("Install() downloaded %d times, want %d", len(requests), tt.wantGets)
+			}
+			if resumed := len(requests) > 0 && requests[len(requests)-1] != ""; resumed != tt.wantResumed {
+				t.Errorf("Install() resumed = %v, want %v (requests %q)", resumed, tt.wantResumed, requests)
+			}
+		})
+	}
+}
+
+func TestInstaller_Install_CorruptCache(t *testing.T) {
+	archive := createTestArchive(t, "jdk.tar.gz", javaEntries(true))
+	inst, installPath, server := newTestInstaller(t, archive, testChecksum(t, archive))
+
+	if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH); err != nil {
+		t.Fatalf("first Install() error = %v", err)
+	}
+	os.RemoveAll(installPath)
+
+	// The cached archive changed on disk since it was verified
+	download := inst.cache.Lookup(server.url, testChecksum(t, archive))
+	os.WriteFile(download.Path(), []byte("corrupt"), 0644)
+
+	if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH); err == nil {
+		t.Fatal("Install() should fail to verify the corrupt archive")
+	}
+	if entries, _ := inst.cache.List(); len(entries) != 0 {
+		t.Errorf("Install() kept %d corrupt cache entries", len(entries))
+	}
+
+	if _, err := inst.Install(context.Background(), models.JavaSDK, "test", "21", runtime.GOARCH); err != nil {
+		t.Fatalf("Install() after removing the corrupt archive error = %v", err)
+	}
+	if requests := server.requests(); len(requests) != 2 {
+		t.Errorf("Install() downloaded %d times, want 2", len(requests))
+	}
+}
@@ -2,10 +2,19 @@ package installer
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"os"
+	"strings"
+)
+
+// Supported checksum algorithms
+const (
+	SHA256 = "sha256"
+	SHA512 = "sha512"
 )
 
 // Verifier handles checksum verification
@@ -22,21 +31,83 @@ func (v *Verifier) VerifyChecksum(filePath, expectedChecksum string) error {
 		return nil // Skip verification if no checksum provided
 	}
 
+	algorithm, expected, err := ParseChecksum(expectedChecksum)
+	if err != nil {
+		return err
+	}
+
+	actualChecksum, err := v.Checksum(filePath, algorithm)
+	if err != nil {
+		return err
+	}
+
+	if actualChecksum != expected {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actualChecksum)
+	}
+
+	return nil
+}
+
+// Checksum calculates the hex encoded digest of a file with the given algorithm
+func (v *Verifier) Checksum(filePath, algorithm string) (string, error) {
+	var h hash.Hash
+	switch algorithm {
+	case SHA256:
+		h = sha256.New()
+	case SHA512:
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum algorithm: %s", algorithm)
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return "", fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to calculate checksum: %w", err)
 	}
 
-	actualChecksum := hex.EncodeToString(hash.Sum(nil))
-	if actualChecksum != expectedChecksum {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedChecksum, actualChecksum)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseChecksum splits a checksum into its algorithm and lower-case hex digest.
+// The algorithm is taken from an optional "sha256:"/"sha512:" prefix, or
+// otherwise inferred from the digest length.
+func ParseChecksum(checksum string) (algorithm, digest string, err error) {
+	digest = strings.ToLower(strings.TrimSpace(checksum))
+	if prefix, rest, ok := strings.Cut(digest, ":"); ok {
+		algorithm, digest = prefix, rest
 	}
 
-	return nil
+	if _, err := hex.DecodeString(digest); err != nil || digest == "" {
+		return "", "", fmt.Errorf("invalid checksum %q: not a hex encoded digest", checksum)
+	}
+
+	switch {
+	case algorithm == "" && len(digest) == sha256.Size*2:
+		algorithm = SHA256
+	case algorithm == "" && len(digest) == sha512.Size*2:
+		algorithm = SHA512
+	case algorithm == "":
+		return "", "", fmt.Errorf("unrecognized checksum length %d: %s", len(digest), checksum)
+	case algorithm == SHA256 && len(digest) != sha256.Size*2,
+		algorithm == SHA512 && len(digest) != sha512.Size*2:
+		return "", "", fmt.Errorf("invalid %s checksum length %d: %s", algorithm, len(digest), checksum)
+	case algorithm != SHA256 && algorithm != SHA512:
+		return "", "", fmt.Errorf("unsupported checksum algorithm: %s", algorithm)
+	}
+
+	return algorithm, digest, nil
+}
+
+// FormatChecksum returns the canonical "<algorithm>:<digest>" form of a checksum
+func FormatChecksum(checksum string) (string, error) {
+	algorithm, digest, err := ParseChecksum(checksum)
+	if err != nil {
+		return "", err
+	}
+	return algorithm + ":" + digest, nil
 }
@@ -0,0 +1,102 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testData = "unosdk"
+	// SHA-256 of testData
+	testSHA256 = "f42d3ab80cbf4c75e1e62291019a061b49069685b7ab742c3450c91fc276e379"
+)
+
+func writeTestFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "archive.zip")
+	if err := os.WriteFile(path, []byte(testData), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestVerifier_VerifyChecksum(t *testing.T) {
+	v := NewVerifier()
+	path := writeTestFile(t)
+
+	sha256Sum, err := v.Checksum(path, SHA256)
+	if err != nil {
+		t.Fatalf("Checksum(sha256) error = %v", err)
+	}
+	if sha256Sum != testSHA256 {
+		t.Fatalf("Checksum(sha256) = %v, want %v", sha256Sum, testSHA256)
+	}
+	sha512Sum, err := v.Checksum(path, SHA512)
+	if err != nil {
+		t.Fatalf("Checksum(sha512) error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		checksum string
+		wantErr  bool
+	}{
+		{"empty checksum skips verification", "", false},
+		{"matching sha256", sha256Sum, false},
+		{"matching sha512", sha512Sum, false},
+		{"prefixed sha256", "sha256:" + sha256Sum, false},
+		{"upper-case digest", strings.ToUpper("sha512:" + sha512Sum), false},
+		{"mismatching sha256", "0" + testSHA256[1:], true},
+		{"algorithm prefix does not match length", "sha512:" + sha256Sum, true},
+		{"unknown length", "abcdef", true},
+		{"not hex", "zz" + sha256Sum[2:], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.VerifyChecksum(path, tt.checksum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sha256Digest := "8d51d1ad5f0e1e1cd5e1e8cd1f0b4f8a1e4b3e1f6a9d4e0c3b2a1f0e9d8c7b6a"
+	sha512Digest := sha256Digest + sha256Digest
+
+	tests := []struct {
+		name          string
+		checksum      string
+		wantAlgorithm string
+		wantDigest    string
+		wantErr       bool
+	}{
+		{"sha256 by length", sha256Digest, SHA256, sha256Digest, false},
+		{"sha512 by length", sha512Digest, SHA512, sha512Digest, false},
+		{"explicit prefix", "sha256:" + sha256Digest, SHA256, sha256Digest, false},
+		{"surrounding whitespace", " " + sha256Digest + "\n", SHA256, sha256Digest, false},
+		{"unsupported algorithm", "md5:" + sha256Digest, "", "", true},
+		{"invalid hex", "xyz", "", "", true},
+		{"truncated sha256", "sha256:" + sha256Digest[:40], "", "", true},
+		{"sha256 prefix on sha512 digest", "sha256:" + sha512Digest, "", "", true},
+		{"truncated sha512", "sha512:" + sha256Digest, "", "", true},
+		{"prefixed invalid hex", "sha256:" + sha256Digest[:62] + "zz", "", "", true},
+		{"empty prefixed digest", "sha512:", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm, digest, err := ParseChecksum(tt.checksum)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if algorithm != tt.wantAlgorithm || digest != tt.wantDigest {
+				t.Errorf("ParseChecksum() = (%v, %v), want (%v, %v)", algorithm, digest, tt.wantAlgorithm, tt.wantDigest)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/javaquery/unosdk/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames lists the files versions are read from, in order of precedence:
+// the unosdk project files, then the version files of other tools
+var FileNames = []string{
+	".unosdkrc", "unosdk.yaml",
+	".tool-versions", ".sdkmanrc", ".nvmrc", ".python-version", ".java-version", "go.mod",
+}
+
+// parsers reads the pins of each file
+var parsers = map[string]func(data []byte) ([]Pin, error){
+	".unosdkrc":       Parse,
+	"unosdk.yaml":     Parse,
+	".tool-versions":  parseToolVersions,
+	".sdkmanrc":       parseSdkmanrc,
+	".nvmrc":          parseNvmrc,
+	".python-version": parsePythonVersion,
+	".java-version":   parseJavaVersionFile,
+	"go.mod":          parseGoMod,
+}
+
+// DefaultProviders are the providers used for versions read from files that
+// name no provider, such as .nvmrc or .python-version
+var DefaultProviders = map[models.SDKType]string{
+	models.JavaSDK:    "openjdk",
+	models.NodeSDK:    "nodejs",
+	models.PythonSDK:  "python",
+	models.MavenSDK:   "apache",
+	models.GradleSDK:  "gradle",
+	models.GoSDK:      "golang",
+	models.FlutterSDK: "flutter",
+}
+
+// Pin is an SDK version a project asks for
+type Pin struct {
+	Type models.SDKType
+	// Provider is empty when the file does not name one
+	Provider string
+	// Version is an exact version, a partial version like "21" or an alias
+	// like "latest" or "lts"
+	Version string
+	// Source is the file the pin was read from
+	Source string
+}
+
+// String returns the pin as install arguments, e.g. "java openjdk 21"
+func (p Pin) String() string {
+	return fmt.Sprintf("%s %s %s", p.Type, p.Provider, p.Version)
+}
+
+// File holds the versions pinned for a directory
+type File struct {
+	// Path is the nearest file that pins a version
+	Path string
+	Pins []Pin
+}
+
+// Pin returns the pin of an SDK type
+func (f *File) Pin(sdkType models.SDKType) (Pin, bool) {
+	for _, pin := range f.Pins {
+		if pin.Type == sdkType {
+			return pin, true
+		}
+	}
+	return Pin{}, false
+}
+
+// Find collects the versions pinned for dir by the files in it and its
+// parent directories. For each SDK type the nearest file wins; in the same
+// directory the files are read in the order of FileNames. Pins without a
+// provider get the one from providers, falling back to DefaultProviders. It
+// returns nil when nothing is pinned.
+func Find(dir string, providers map[models.SDKType]string) (*File, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	file := &File{}
+	seen := make(map[models.SDKType]bool)
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			pins, err := parsers[name](data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version file %s: %w", path, err)
+			}
+
+			for _, pin := range pins {
+				if seen[pin.Type] {
+					continue
+				}
+				if pin.Provider == "" {
+					pin.Provider = defaultProvider(pin.Type, providers)
+				}
+				if pin.Provider == "" {
+					continue
+				}
+				seen[pin.Type] = true
+				pin.Source = path
+				file.Pins = append(file.Pins, pin)
+				if file.Path == "" {
+					file.Path = path
+				}
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if len(file.Pins) == 0 {
+		return nil, nil
+	}
+	return file, nil
+}
+
+// defaultProvider returns the provider of an SDK type for files naming none
+func defaultProvider(sdkType models.SDKType, providers map[models.SDKType]string) string {
+	if provider := providers[sdkType]; provider != "" {
+		return provider
+	}
+	return DefaultProviders[sdkType]
+}
+
+// Parse parses a project file, a YAML mapping of SDK types to
+// "<provider> <version>", keeping the order of the file:
+//
+//	java: openjdk 21
+//	node: nodejs lts
+func Parse(data []byte) ([]Pin, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of SDK types to versions", root.Line)
+	}
+
+	var pins []Pin
+	seen := make(map[models.SDKType]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: %s must be \"<provider> <version>\"", value.Line, key.Value)
+		}
+
+		fields := strings.Fields(value.Value)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: %s must be \"<provider> <version>\", got %q", value.Line, key.Value, value.Value)
+		}
+
+		sdkType := models.SDKType(strings.ToLower(key.Value))
+		if seen[sdkType] {
+			return nil, fmt.Errorf("line %d: %s is pinned twice", key.Line, sdkType)
+		}
+		seen[sdkType] = true
+
+		pins = append(pins, Pin{Type: sdkType, Provider: fields[0], Version: fields[1]})
+	}
+
+	return pins, nil
+}
+
+// Match returns the newest installed SDK satisfying a pin: the exact version,
+// or the newest installed release of a partial version ("21" matches
+// "21.0.5"). Aliases such as "lts" must be resolved by the provider first.
+func Match(installed []*models.SDK, pin Pin) (*models.SDK, bool) {
+	var best *models.SDK
+	for _, sdk := range installed {
+		if sdk.Type != pin.Type || sdk.Provider != pin.Provider {
+			continue
+		}
+		if sdk.Version == pin.Version {
+			return sdk, true
+		}
+		if !strings.HasPrefix(sdk.Version, pin.Version+".") {
+			continue
+		}
+		if best == nil || models.CompareVersions(sdk.Version, best.Version) > 0 {
+			best = sdk
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,181 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Pin
+		wantErr bool
+	}{
+		{
+			name: "keeps file order",
+			data: "node: nodejs lts\njava: openjdk 21\nmaven: apache 3.9.9\n",
+			want: []Pin{
+				{Type: models.NodeSDK, Provider: "nodejs", Version: "lts"},
+				{Type: models.JavaSDK, Provider: "openjdk", Version: "21"},
+				{Type: models.MavenSDK, Provider: "apache", Version: "3.9.9"},
+			},
+		},
+		{
+			name: "empty file",
+			data: "# no pins yet\n",
+			want: nil,
+		},
+		{
+			name:    "missing version",
+			data:    "java: openjdk\n",
+			wantErr: true,
+		},
+		{
+			name:    "nested value",
+			data:    "java:\n  provider: openjdk\n",
+			wantErr: true,
+		},
+		{
+			name:    "pinned twice",
+			data:    "java: openjdk 21\njava: amazoncorretto 17\n",
+			wantErr: true,
+		},
+		{
+			name:    "not a mapping",
+			data:    "- java\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "service", "src", "main")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "unosdk.yaml"), []byte("java: openjdk 21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := Find(nested, nil)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if file == nil || file.Path != filepath.Join(root, "unosdk.yaml") {
+		t.Fatalf("Find() = %v, want the file in %v", file, root)
+	}
+	if pin, ok := file.Pin(models.JavaSDK); !ok || pin.Version != "21" {
+		t.Errorf("Pin(java) = %v, %v, want openjdk 21", pin, ok)
+	}
+
+	// The nearest file wins
+	service := filepath.Join(root, "service")
+	if err := os.WriteFile(filepath.Join(service, ".unosdkrc"), []byte("java: openjdk 17\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err = Find(nested, nil)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if file == nil || file.Path != filepath.Join(service, ".unosdkrc") {
+		t.Errorf("Find() = %v, want the file in %v", file, service)
+	}
+}
+
+func TestFind_OtherTools(t *testing.T) {
+	root := t.TempDir()
+	web := filepath.Join(root, "web")
+	if err := os.MkdirAll(web, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(root, ".sdkmanrc"):      "java=21.0.2-tem\nmaven=3.9.9\n",
+		filepath.Join(root, ".java-version"):  "17\n",
+		filepath.Join(root, ".nvmrc"):         "18\n",
+		filepath.Join(web, ".nvmrc"):          "lts/*\n",
+		filepath.Join(web, ".python-version"): "3.12.1\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := Find(web, map[models.SDKType]string{models.PythonSDK: "mypython"})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if file == nil {
+		t.Fatal("Find() = nil, want the pins of both directories")
+	}
+
+	want := map[models.SDKType]Pin{
+		// The nearest file wins
+		models.NodeSDK: {Type: models.NodeSDK, Provider: "nodejs", Version: "lts", Source: filepath.Join(web, ".nvmrc")},
+		// The configured provider replaces the default one
+		models.PythonSDK: {Type: models.PythonSDK, Provider: "mypython", Version: "3.12.1", Source: filepath.Join(web, ".python-version")},
+		// .sdkmanrc is read before .java-version in the same directory
+		models.JavaSDK:  {Type: models.JavaSDK, Provider: "openjdk", Version: "21.0.2", Source: filepath.Join(root, ".sdkmanrc")},
+		models.MavenSDK: {Type: models.MavenSDK, Provider: "apache", Version: "3.9.9", Source: filepath.Join(root, ".sdkmanrc")},
+	}
+	if len(file.Pins) != len(want) {
+		t.Errorf("Find() pins = %v, want %d pins", file.Pins, len(want))
+	}
+	for sdkType, wantPin := range want {
+		if got, ok := file.Pin(sdkType); !ok || got != wantPin {
+			t.Errorf("Pin(%s) = %+v, want %+v", sdkType, got, wantPin)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	installed := []*models.SDK{
+		{Type: models.JavaSDK, Provider: "openjdk", Version: "21"},
+		{Type: models.NodeSDK, Provider: "nodejs", Version: "20.9.0"},
+		{Type: models.NodeSDK, Provider: "nodejs", Version: "20.10.0"},
+		{Type: models.NodeSDK, Provider: "nodejs", Version: "22.11.0"},
+	}
+
+	tests := []struct {
+		name string
+		pin  Pin
+		want string
+	}{
+		{name: "exact", pin: Pin{Type: models.JavaSDK, Provider: "openjdk", Version: "21"}, want: "21"},
+		{name: "newest of partial version", pin: Pin{Type: models.NodeSDK, Provider: "nodejs", Version: "20"}, want: "20.10.0"},
+		{name: "no prefix match inside a component", pin: Pin{Type: models.NodeSDK, Provider: "nodejs", Version: "2"}, want: ""},
+		{name: "other provider", pin: Pin{Type: models.JavaSDK, Provider: "amazoncorretto", Version: "21"}, want: ""},
+		{name: "alias", pin: Pin{Type: models.NodeSDK, Provider: "nodejs", Version: "lts"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk, ok := Match(installed, tt.pin)
+			got := ""
+			if ok {
+				got = sdk.Version
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// asdfTools maps asdf plugin names to SDK types
+var asdfTools = map[string]models.SDKType{
+	"java":    models.JavaSDK,
+	"nodejs":  models.NodeSDK,
+	"node":    models.NodeSDK,
+	"python":  models.PythonSDK,
+	"maven":   models.MavenSDK,
+	"gradle":  models.GradleSDK,
+	"golang":  models.GoSDK,
+	"go":      models.GoSDK,
+	"flutter": models.FlutterSDK,
+}
+
+// sdkmanCandidates maps SDKMAN candidates to SDK types
+var sdkmanCandidates = map[string]models.SDKType{
+	"java":   models.JavaSDK,
+	"maven":  models.MavenSDK,
+	"gradle": models.GradleSDK,
+}
+
+// javaVendors maps the vendor names and SDKMAN identifiers used in Java
+// version strings to providers
+var javaVendors = map[string]string{
+	"tem":          "openjdk",
+	"temurin":      "openjdk",
+	"adoptopenjdk": "openjdk",
+	"adopt":        "openjdk",
+	"open":         "openjdk",
+	"openjdk":      "openjdk",
+	"amzn":         "amazoncorretto",
+	"corretto":     "amazoncorretto",
+	"graal":        "graalvm",
+	"graalce":      "graalvm",
+	"graalvm":      "graalvm",
+}
+
+// parseToolVersions parses an asdf .tool-versions file ("nodejs 20.10.0").
+// The first version of each tool is used, tools unosdk does not manage are
+// skipped.
+func parseToolVersions(data []byte) ([]Pin, error) {
+	var pins []Pin
+	for _, line := range lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		sdkType, ok := asdfTools[fields[0]]
+		if !ok {
+			continue
+		}
+
+		pin := Pin{Type: sdkType, Version: fields[1]}
+		switch sdkType {
+		case models.JavaSDK:
+			pin.Provider, pin.Version = parseJavaVersion(fields[1])
+		case models.FlutterSDK:
+			pin.Version = strings.TrimSuffix(pin.Version, "-stable")
+		}
+		// Skips "system", "ref:..." and "path:..." versions
+		if isVersion(pin.Version) {
+			pins = append(pins, pin)
+		}
+	}
+	return pins, nil
+}
+
+// parseSdkmanrc parses an SDKMAN .sdkmanrc file ("java=21.0.2-tem")
+func parseSdkmanrc(data []byte) ([]Pin, error) {
+	var pins []Pin
+	for _, line := range lines(data) {
+		name, version, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		sdkType, ok := sdkmanCandidates[strings.TrimSpace(name)]
+		if !ok {
+			continue
+		}
+		version = strings.TrimSpace(version)
+
+		pin := Pin{Type: sdkType, Version: version}
+		if sdkType == models.JavaSDK {
+			pin.Provider, pin.Version = parseJavaVersion(version)
+		}
+		if isVersion(pin.Version) {
+			pins = append(pins, pin)
+		}
+	}
+	return pins, nil
+}
+
+// parseNvmrc parses an nvm .nvmrc file ("20", "v20.10.0", "lts/*", "node")
+func parseNvmrc(data []byte) ([]Pin, error) {
+	version := firstLine(data)
+	switch version {
+	case "":
+		return nil, nil
+	case "node", "stable":
+		version = "latest"
+	case "lts/*":
+		version = "lts"
+	}
+	return []Pin{{Type: models.NodeSDK, Version: strings.TrimPrefix(version, "v")}}, nil
+}
+
+// parsePythonVersion parses a pyenv .python-version file. Versions of other
+// implementations (pypy3.10, system) are skipped.
+func parsePythonVersion(data []byte) ([]Pin, error) {
+	version := firstLine(data)
+	if !isVersion(version) {
+		return nil, nil
+	}
+	return []Pin{{Type: models.PythonSDK, Version: version}}, nil
+}
+
+// parseJavaVersionFile parses a jenv .java-version file ("17",
+// "temurin64-21.0.2")
+func parseJavaVersionFile(data []byte) ([]Pin, error) {
+	provider, version := parseJavaVersion(firstLine(data))
+	if !isVersion(version) {
+		return nil, nil
+	}
+	return []Pin{{Type: models.JavaSDK, Provider: provider, Version: version}}, nil
+}
+
+// parseGoMod reads the toolchain directive of a go.mod file ("toolchain go1.23.5")
+func parseGoMod(data []byte) ([]Pin, error) {
+	for _, line := range lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "toolchain" && strings.HasPrefix(fields[1], "go") {
+			version := strings.TrimPrefix(fields[1], "go")
+			if !isVersion(version) {
+				return nil, nil
+			}
+			return []Pin{{Type: models.GoSDK, Version: version}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseJavaVersion splits a Java version string into a provider and a
+// version. It understands SDKMAN identifiers ("21.0.2-tem"), asdf and jenv
+// names ("temurin-21.0.2+13.0.LTS", "corretto64-17") and plain versions.
+// The provider is empty when the vendor is unknown or not named.
+func parseJavaVersion(value string) (string, string) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+
+	// Leading words name the vendor (asdf, jenv)
+	i := 0
+	for i < len(parts) && !isVersion(parts[i]) {
+		i++
+	}
+	vendor := ""
+	if i > 0 {
+		vendor = strings.TrimSuffix(strings.ToLower(parts[0]), "64")
+	}
+
+	// A trailing word is an SDKMAN vendor identifier
+	rest := parts[i:]
+	if vendor == "" && len(rest) > 1 {
+		vendor = strings.ToLower(rest[len(rest)-1])
+		rest = rest[:len(rest)-1]
+	}
+
+	version := strings.Join(rest, "-")
+	if build := strings.Index(version, "+"); build >= 0 {
+		version = version[:build]
+	}
+	return javaVendors[vendor], version
+}
+
+// lines returns the non-empty lines of a file without comments
+func lines(data []byte) []string {
+	var result []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// firstLine returns the first non-empty line of a file without comments
+func firstLine(data []byte) string {
+	if all := lines(data); len(all) > 0 {
+		return all[0]
+	}
+	return ""
+}
+
+// isVersion reports whether s starts like a version number
+func isVersion(s string) bool {
+	s = strings.TrimPrefix(s, "v")
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
@@ -0,0 +1,127 @@
+package project
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestParseJavaVersion(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantProvider string
+		wantVersion  string
+	}{
+		{value: "21", wantProvider: "", wantVersion: "21"},
+		{value: "17.0.2", wantProvider: "", wantVersion: "17.0.2"},
+		{value: "21.0.2-tem", wantProvider: "openjdk", wantVersion: "21.0.2"},
+		{value: "17.0.9-amzn", wantProvider: "amazoncorretto", wantVersion: "17.0.9"},
+		{value: "21.0.1-graalce", wantProvider: "graalvm", wantVersion: "21.0.1"},
+		{value: "21.0.2-zulu", wantProvider: "", wantVersion: "21.0.2"},
+		{value: "temurin-21.0.2+13.0.LTS", wantProvider: "openjdk", wantVersion: "21.0.2"},
+		{value: "corretto-17.0.9.8.1", wantProvider: "amazoncorretto", wantVersion: "17.0.9.8.1"},
+		{value: "graalvm-community-21.0.1", wantProvider: "graalvm", wantVersion: "21.0.1"},
+		{value: "openjdk64-17.0.2", wantProvider: "openjdk", wantVersion: "17.0.2"},
+		{value: "system", wantProvider: "", wantVersion: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			provider, version := parseJavaVersion(tt.value)
+			if provider != tt.wantProvider || version != tt.wantVersion {
+				t.Errorf("parseJavaVersion() = %q, %q, want %q, %q", provider, version, tt.wantProvider, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestSourceParsers(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func([]byte) ([]Pin, error)
+		data  string
+		want  []Pin
+	}{
+		{
+			name:  "tool-versions",
+			parse: parseToolVersions,
+			data:  "# asdf\nnodejs 20.10.0 18.19.0\njava temurin-21.0.2+13.0.LTS\nruby 3.3.0\npython system\ngolang 1.23.5\nflutter 3.24.0-stable\n",
+			want: []Pin{
+				{Type: models.NodeSDK, Version: "20.10.0"},
+				{Type: models.JavaSDK, Provider: "openjdk", Version: "21.0.2"},
+				{Type: models.GoSDK, Version: "1.23.5"},
+				{Type: models.FlutterSDK, Version: "3.24.0"},
+			},
+		},
+		{
+			name:  "sdkmanrc",
+			parse: parseSdkmanrc,
+			data:  "# Enable auto-env through the sdkman_auto_env config\njava=17.0.9-amzn\nmaven=3.9.9\nkotlin=1.9.22\n",
+			want: []Pin{
+				{Type: models.JavaSDK, Provider: "amazoncorretto", Version: "17.0.9"},
+				{Type: models.MavenSDK, Version: "3.9.9"},
+			},
+		},
+		{
+			name:  "nvmrc with v prefix",
+			parse: parseNvmrc,
+			data:  "v20.10.0\n",
+			want:  []Pin{{Type: models.NodeSDK, Version: "20.10.0"}},
+		},
+		{
+			name:  "nvmrc lts",
+			parse: parseNvmrc,
+			data:  "lts/*\n",
+			want:  []Pin{{Type: models.NodeSDK, Version: "lts"}},
+		},
+		{
+			name:  "nvmrc codename",
+			parse: parseNvmrc,
+			data:  "lts/iron\n",
+			want:  []Pin{{Type: models.NodeSDK, Version: "lts/iron"}},
+		},
+		{
+			name:  "python-version",
+			parse: parsePythonVersion,
+			data:  "3.12.1\n3.11.7\n",
+			want:  []Pin{{Type: models.PythonSDK, Version: "3.12.1"}},
+		},
+		{
+			name:  "python-version of another implementation",
+			parse: parsePythonVersion,
+			data:  "pypy3.10-7.3.15\n",
+			want:  nil,
+		},
+		{
+			name:  "java-version",
+			parse: parseJavaVersionFile,
+			data:  "corretto64-17.0.9.8.1\n",
+			want:  []Pin{{Type: models.JavaSDK, Provider: "amazoncorretto", Version: "17.0.9.8.1"}},
+		},
+		{
+			name:  "go.mod toolchain",
+			parse: parseGoMod,
+			data:  "module example.com/app\n\ngo 1.23\n\ntoolchain go1.23.5\n",
+			want:  []Pin{{Type: models.GoSDK, Version: "1.23.5"}},
+		},
+		{
+			name:  "go.mod without toolchain",
+			parse: parseGoMod,
+			data:  "module example.com/app\n\ngo 1.23\n",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -64,6 +64,7 @@ func TestMinGWProvider_GetLatestVersion(t *testing.T) {
 
 func TestMinGWProvider_GetDownloadURL(t *testing.T) {
 	p := NewMinGWProvider()
+	p.goos = "windows"
 
 	testCases := []struct {
 		version  string
@@ -121,3 +122,12 @@ func TestMinGWProvider_Validate(t *testing.T) {
 		t.Error("expected error for empty version")
 	}
 }
+
+func TestMinGWProvider_GetDownloadURL_Unix(t *testing.T) {
+	p := NewMinGWProvider()
+	p.goos = "linux"
+
+	if _, err := p.GetDownloadURL("15.2.0", "x64"); err == nil {
+		t.Error("expected error for MinGW on linux")
+	}
+}
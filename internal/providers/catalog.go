@@ -0,0 +1,74 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/javaquery/unosdk/configs"
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/providers/c"
+	"github.com/javaquery/unosdk/internal/providers/cpp"
+	"github.com/javaquery/unosdk/internal/providers/declarative"
+	"github.com/javaquery/unosdk/internal/providers/flutter"
+	"github.com/javaquery/unosdk/internal/providers/go"
+	"github.com/javaquery/unosdk/internal/providers/gradle"
+	"github.com/javaquery/unosdk/internal/providers/java"
+	"github.com/javaquery/unosdk/internal/providers/maven"
+	"github.com/javaquery/unosdk/internal/providers/node"
+	"github.com/javaquery/unosdk/internal/providers/python"
+	"github.com/javaquery/unosdk/internal/registry"
+)
+
+// Default returns a registry containing every built-in provider followed by
+// the declarative providers of the built-in sdks.yaml and the user's
+// ~/.unosdk/sdks.yaml, with a synced registry manifest applied on top. It is
+// the source of truth for the SDK types and providers unosdk supports, and
+// its order determines the order in which they are listed.
+func Default() *Registry {
+	catalog := NewRegistry()
+
+	catalog.Register(java.NewAmazonCorrettoProvider())
+	catalog.Register(java.NewOpenJDKProvider())
+	catalog.Register(java.NewGraalVMProvider())
+	catalog.Register(node.NewNodeJSProvider())
+	catalog.Register(python.NewPythonProvider())
+	catalog.Register(flutter.NewFlutterProvider())
+	catalog.Register(maven.NewMavenProvider())
+	catalog.Register(gradle.NewGradleProvider())
+	catalog.Register(golang.NewGoProvider())
+	catalog.Register(cpp.NewMinGWProvider())
+	catalog.Register(c.NewMinGWProvider())
+
+	builtin, err := declarative.Parse(configs.SDKs)
+	registerDeclarative(catalog, "built-in "+declarative.FileName, builtin, err)
+
+	// User-level providers are registered last so they can replace any other
+	if cfg, err := config.New(); err == nil {
+		userFile := filepath.Join(cfg.ConfigDir, declarative.FileName)
+		user, err := declarative.LoadFile(userFile)
+		registerDeclarative(catalog, userFile, user, err)
+	}
+
+	// A synced registry manifest takes precedence over compiled-in versions
+	manifest, err := registry.LoadDefaultManifest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: ignoring synced registry manifest: %v\n", err)
+	}
+	ApplyManifest(catalog, manifest)
+
+	return catalog
+}
+
+// registerDeclarative registers providers loaded from a file, warning
+// instead of failing when the file is invalid
+func registerDeclarative(catalog *Registry, source string, providers []*declarative.Provider, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: ignoring providers from %s: %v\n", source, err)
+		return
+	}
+
+	for _, provider := range providers {
+		catalog.Register(provider)
+	}
+}
@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/javaquery/unosdk/internal/providers/mingw"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // MinGWProvider implements the Provider interface for MinGW-w64 C++ toolchain
-type MinGWProvider struct{}
+type MinGWProvider struct {
+	goos string
+}
 
 // NewMinGWProvider creates a new MinGW-w64 provider
 func NewMinGWProvider() *MinGWProvider {
-	return &MinGWProvider{}
+	return &MinGWProvider{
+		goos: runtime.GOOS,
+	}
 }
 
 func (p *MinGWProvider) Name() string {
@@ -39,13 +44,14 @@ func (p *MinGWProvider) GetLatestVersion(ctx context.Context) (string, error) {
 }
 
 func (p *MinGWProvider) GetDownloadURL(version string, arch string) (string, error) {
+	if err := mingw.CheckOS(p.goos); err != nil {
+		return "", err
+	}
 	return mingw.GetDownloadURL(version, arch)
 }
 
-func (p *MinGWProvider) GetChecksum(version string, arch string) (string, error) {
-	// Checksums would typically be downloaded from the release page
-	// For now, returning empty string (optional verification)
-	return "", nil
+func (p *MinGWProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	return mingw.GetChecksum(ctx, version, arch)
 }
 
 func (p *MinGWProvider) GetDefaultInstallPath(version string) string {
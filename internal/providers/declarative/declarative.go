@@ -0,0 +1,247 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/providers/remote"
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the declarative provider file in the unosdk config directory
+const FileName = "sdks.yaml"
+
+// File is the layout of an sdks.yaml file
+type File struct {
+	Providers []Spec `yaml:"providers"`
+}
+
+// Spec declares a provider
+type Spec struct {
+	Type        string            `yaml:"type"`
+	Name        string            `yaml:"name"`
+	DisplayName string            `yaml:"display_name"`
+	URL         string            `yaml:"url"`
+	Arch        map[string]string `yaml:"arch"`
+	OS          map[string]string `yaml:"os"`
+	ChecksumURL string            `yaml:"checksum_url"`
+	Versions    []string          `yaml:"versions"`
+	VersionsURL *VersionsSource   `yaml:"versions_url"`
+	Env         models.EnvSpec    `yaml:"env"`
+}
+
+// VersionsSource is an endpoint listing the available versions as a JSON
+// array of strings, or of objects holding the version in Field
+type VersionsSource struct {
+	URL        string `yaml:"url"`
+	Field      string `yaml:"field"`
+	TrimPrefix string `yaml:"trim_prefix"`
+}
+
+// validate checks that the spec has everything needed to install from it
+func (s Spec) validate() error {
+	switch {
+	case s.Type == "":
+		return fmt.Errorf("type is required")
+	case s.Name == "":
+		return fmt.Errorf("name is required")
+	case s.URL == "":
+		return fmt.Errorf("url is required")
+	case len(s.Versions) == 0 && (s.VersionsURL == nil || s.VersionsURL.URL == ""):
+		return fmt.Errorf("versions or versions_url is required")
+	}
+	return nil
+}
+
+// Provider implements the Provider interface for a provider declared in YAML
+type Provider struct {
+	spec  Spec
+	goos  string
+	cache *registry.Cache
+}
+
+// NewProvider creates a provider from a spec
+func NewProvider(spec Spec) (*Provider, error) {
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("invalid provider %s:%s: %w", spec.Type, spec.Name, err)
+	}
+	if spec.DisplayName == "" {
+		spec.DisplayName = spec.Name
+	}
+
+	return &Provider{
+		spec:  spec,
+		goos:  runtime.GOOS,
+		cache: registry.NewDefaultCache(),
+	}, nil
+}
+
+// Parse parses an sdks.yaml document into providers
+func Parse(data []byte) ([]*Provider, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse providers: %w", err)
+	}
+
+	providers := make([]*Provider, 0, len(file.Providers))
+	for _, spec := range file.Providers {
+		provider, err := NewProvider(spec)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// LoadFile reads providers from an sdks.yaml file. A missing file yields no providers.
+func LoadFile(filePath string) ([]*Provider, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	return Parse(data)
+}
+
+func (p *Provider) Name() string {
+	return p.spec.Name
+}
+
+func (p *Provider) DisplayName() string {
+	return p.spec.DisplayName
+}
+
+func (p *Provider) Type() models.SDKType {
+	return models.SDKType(p.spec.Type)
+}
+
+// GetVersions returns the declared or discovered versions, newest first
+func (p *Provider) GetVersions(ctx context.Context) ([]string, error) {
+	versions := p.spec.Versions
+	if len(versions) == 0 {
+		discovered, err := p.discoverVersions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		versions = discovered
+	}
+
+	sorted := append([]string(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return models.CompareVersions(sorted[i], sorted[j]) > 0
+	})
+	return sorted, nil
+}
+
+func (p *Provider) GetLatestVersion(ctx context.Context) (string, error) {
+	versions, err := p.GetVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions available for %s", p.spec.Name)
+	}
+	return versions[0], nil
+}
+
+func (p *Provider) GetDownloadURL(version string, arch string) (string, error) {
+	return p.expand(p.spec.URL, version, arch), nil
+}
+
+// GetChecksum fetches the checksum from checksum_url, if one is declared
+func (p *Provider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	if p.spec.ChecksumURL == "" {
+		return "", nil
+	}
+
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	return remote.FetchChecksum(ctx, p.expand(p.spec.ChecksumURL, version, arch), path.Base(downloadURL))
+}
+
+func (p *Provider) GetDefaultInstallPath(version string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".unosdk", p.spec.Type, p.spec.Name, version)
+}
+
+func (p *Provider) Validate(version string) error {
+	if version == "" {
+		return fmt.Errorf("version cannot be empty")
+	}
+	return nil
+}
+
+// EnvSpec returns the declared environment rules
+func (p *Provider) EnvSpec() models.EnvSpec {
+	return p.spec.Env
+}
+
+// expand replaces the {version}, {arch} and {os} placeholders of a URL template
+func (p *Provider) expand(template, version, arch string) string {
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+	if mapped, ok := p.spec.Arch[arch]; ok {
+		arch = mapped
+	}
+
+	goos := p.goos
+	if mapped, ok := p.spec.OS[goos]; ok {
+		goos = mapped
+	}
+
+	return strings.NewReplacer(
+		"{version}", version,
+		"{arch}", arch,
+		"{os}", goos,
+	).Replace(template)
+}
+
+// discoverVersions queries versions_url, caching the result
+func (p *Provider) discoverVersions(ctx context.Context) ([]string, error) {
+	key := fmt.Sprintf("declarative_%s_%s", p.spec.Type, p.spec.Name)
+	var versions []string
+	if p.cache.Load(key, &versions) {
+		return versions, nil
+	}
+
+	source := p.spec.VersionsURL
+	var items []interface{}
+	if err := remote.FetchJSON(ctx, source.URL, &items); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s versions: %w", p.spec.Name, err)
+	}
+
+	for _, item := range items {
+		var version string
+		switch value := item.(type) {
+		case string:
+			version = value
+		case map[string]interface{}:
+			version, _ = value[source.Field].(string)
+		}
+
+		version = strings.TrimPrefix(version, source.TrimPrefix)
+		if version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	p.cache.Set(key, versions)
+	return versions, nil
+}
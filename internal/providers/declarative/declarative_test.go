@@ -0,0 +1,223 @@
+package declarative
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+const testFile = `
+providers:
+  - type: protoc
+    name: protobuf
+    display_name: Protocol Buffers Compiler
+    url: https://example.com/v{version}/protoc-{version}-{os}-{arch}.zip
+    checksum_url: https://example.com/v{version}/SHA256SUMS
+    arch:
+      amd64: x86_64
+    os:
+      windows: win64
+    versions: ["28.3", "29.3", "3.20.3"]
+    env:
+      vars:
+        PROTOC_HOME: "{install_path}"
+      path:
+        - bin
+`
+
+func parseOne(t *testing.T, data string) *Provider {
+	t.Helper()
+	providers, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(providers) != 1 {
+		t.Fatalf("Parse() returned %d providers, want 1", len(providers))
+	}
+	providers[0].cache = registry.NewCache(t.TempDir(), time.Hour)
+	return providers[0]
+}
+
+func TestParse(t *testing.T) {
+	provider := parseOne(t, testFile)
+
+	if provider.Type() != models.SDKType("protoc") {
+		t.Errorf("Type() = %v, want %v", provider.Type(), "protoc")
+	}
+	if provider.Name() != "protobuf" {
+		t.Errorf("Name() = %v, want %v", provider.Name(), "protobuf")
+	}
+	if provider.DisplayName() != "Protocol Buffers Compiler" {
+		t.Errorf("DisplayName() = %v, want %v", provider.DisplayName(), "Protocol Buffers Compiler")
+	}
+	if got := provider.EnvSpec().Vars["PROTOC_HOME"]; got != "{install_path}" {
+		t.Errorf("EnvSpec().Vars[PROTOC_HOME] = %v, want %v", got, "{install_path}")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed yaml", "providers: ["},
+		{"missing type", "providers:\n  - name: x\n    url: u\n    versions: [\"1\"]"},
+		{"missing name", "providers:\n  - type: x\n    url: u\n    versions: [\"1\"]"},
+		{"missing url", "providers:\n  - type: x\n    name: x\n    versions: [\"1\"]"},
+		{"missing versions", "providers:\n  - type: x\n    name: x\n    url: u"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); err == nil {
+				t.Error("Parse() should fail")
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	providers, err := LoadFile(filepath.Join(dir, FileName))
+	if err != nil || len(providers) != 0 {
+		t.Errorf("LoadFile() of missing file = %v, %v, want no providers", providers, err)
+	}
+
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(testFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	providers, err = LoadFile(path)
+	if err != nil || len(providers) != 1 {
+		t.Errorf("LoadFile() = %v, %v, want one provider", providers, err)
+	}
+}
+
+func TestProvider_GetVersions(t *testing.T) {
+	provider := parseOne(t, testFile)
+
+	versions, err := provider.GetVersions(context.Background())
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	if want := []string{"29.3", "28.3", "3.20.3"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("GetVersions() = %v, want %v", versions, want)
+	}
+
+	latest, err := provider.GetLatestVersion(context.Background())
+	if err != nil || latest != "29.3" {
+		t.Errorf("GetLatestVersion() = %v, %v, want %v", latest, err, "29.3")
+	}
+}
+
+func TestProvider_DiscoverVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases":
+			w.Write([]byte(`[{"tag_name":"v1.2.0"},{"tag_name":"v1.10.0"},{"name":"no tag"}]`))
+		case "/versions":
+			w.Write([]byte(`["2.0.0","2.1.0"]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"objects", "versions_url:\n      url: " + server.URL + "/releases\n      field: tag_name\n      trim_prefix: v", []string{"1.10.0", "1.2.0"}},
+		{"strings", "versions_url:\n      url: " + server.URL + "/versions", []string{"2.1.0", "2.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := parseOne(t, "providers:\n  - type: tool\n    name: tool\n    url: u\n    "+tt.source)
+
+			versions, err := provider.GetVersions(context.Background())
+			if err != nil {
+				t.Fatalf("GetVersions() error = %v", err)
+			}
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("GetVersions() = %v, want %v", versions, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_GetDownloadURL(t *testing.T) {
+	provider := parseOne(t, testFile)
+
+	tests := []struct {
+		name string
+		goos string
+		arch string
+		want string
+	}{
+		{"mapped os and arch", "windows", "amd64", "https://example.com/v29.3/protoc-29.3-win64-x86_64.zip"},
+		{"unmapped os and arch", "linux", "arm64", "https://example.com/v29.3/protoc-29.3-linux-arm64.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.goos = tt.goos
+			got, err := provider.GetDownloadURL("29.3", tt.arch)
+			if err != nil {
+				t.Fatalf("GetDownloadURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_GetChecksum(t *testing.T) {
+	sha256 := strings.Repeat("cd", 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v29.3/SHA256SUMS" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(strings.Repeat("00", 32) + "  protoc-29.3-linux-x86_64.zip\n" + sha256 + "  protoc-29.3-win64-x86_64.zip\n"))
+	}))
+	defer server.Close()
+
+	provider := parseOne(t, strings.ReplaceAll(testFile, "https://example.com", server.URL))
+	provider.goos = "windows"
+
+	got, err := provider.GetChecksum(context.Background(), "29.3", "amd64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if got != sha256 {
+		t.Errorf("GetChecksum() = %v, want %v", got, sha256)
+	}
+
+	// Without checksum_url verification is skipped
+	provider.spec.ChecksumURL = ""
+	if got, err := provider.GetChecksum(context.Background(), "29.3", "amd64"); err != nil || got != "" {
+		t.Errorf("GetChecksum() = %v, %v, want empty checksum", got, err)
+	}
+}
+
+func TestProvider_GetDefaultInstallPath(t *testing.T) {
+	provider := parseOne(t, testFile)
+
+	path := provider.GetDefaultInstallPath("29.3")
+	if want := filepath.Join(".unosdk", "protoc", "protobuf", "29.3"); !strings.HasSuffix(path, want) {
+		t.Errorf("GetDefaultInstallPath() = %v, want suffix %v", path, want)
+	}
+}
@@ -5,16 +5,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // FlutterProvider implements the Provider interface for Flutter SDK
-type FlutterProvider struct{}
+type FlutterProvider struct {
+	baseURL string
+	goos    string
+}
 
 // NewFlutterProvider creates a new Flutter provider
 func NewFlutterProvider() *FlutterProvider {
-	return &FlutterProvider{}
+	return &FlutterProvider{
+		baseURL: "https://storage.googleapis.com/flutter_infra_release/releases",
+		goos:    runtime.GOOS,
+	}
 }
 
 func (p *FlutterProvider) Name() string {
@@ -48,24 +57,71 @@ func (p *FlutterProvider) GetLatestVersion(ctx context.Context) (string, error)
 }
 
 func (p *FlutterProvider) GetDownloadURL(version string, arch string) (string, error) {
-	// Flutter uses the same package for all Windows architectures
-	// The Flutter SDK is platform-specific but not architecture-specific on Windows
-	
+	// Flutter uses the same package for all Windows and Linux architectures,
+	// only macOS has a separate Apple Silicon build
+
+	// Flutter download URLs format: https://storage.googleapis.com/flutter_infra_release/releases/stable/windows/flutter_windows_{version}-stable.zip
+	archive, err := p.archive(version, arch)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", p.baseURL, archive), nil
+}
+
+func (p *FlutterProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	archive, err := p.archive(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// The releases manifest records the SHA-256 of every published archive
+	var manifest struct {
+		Releases []struct {
+			Archive string `json:"archive"`
+			SHA256  string `json:"sha256"`
+		} `json:"releases"`
+	}
+	if err := remote.FetchJSON(ctx, fmt.Sprintf("%s/releases_%s.json", p.baseURL, p.flutterOS()), &manifest); err != nil {
+		return "", err
+	}
+
+	for _, release := range manifest.Releases {
+		if release.Archive == archive {
+			return strings.ToLower(release.SHA256), nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s not found", archive)
+}
+
+// archive returns the archive path relative to the releases base URL
+func (p *FlutterProvider) archive(version string, arch string) (string, error) {
 	// Handle "latest" version
 	if version == "latest" {
 		version = "3.41.5"
 	}
 
-	// Flutter download URLs format: https://storage.googleapis.com/flutter_infra_release/releases/stable/windows/flutter_windows_{version}-stable.zip
-	baseURL := "https://storage.googleapis.com/flutter_infra_release/releases/stable/windows"
-	fileName := fmt.Sprintf("flutter_windows_%s-stable.zip", version)
-	
-	return fmt.Sprintf("%s/%s", baseURL, fileName), nil
+	switch p.goos {
+	case "windows":
+		return fmt.Sprintf("stable/windows/flutter_windows_%s-stable.zip", version), nil
+	case "linux":
+		return fmt.Sprintf("stable/linux/flutter_linux_%s-stable.tar.xz", version), nil
+	case "darwin":
+		if arch == "arm64" || arch == "aarch64" {
+			return fmt.Sprintf("stable/macos/flutter_macos_arm64_%s-stable.zip", version), nil
+		}
+		return fmt.Sprintf("stable/macos/flutter_macos_%s-stable.zip", version), nil
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", p.goos)
+	}
 }
 
-func (p *FlutterProvider) GetChecksum(version string, arch string) (string, error) {
-	// In production, this would fetch the actual checksum from Flutter releases
-	return "", nil
+// flutterOS maps the target operating system to Flutter's naming convention
+func (p *FlutterProvider) flutterOS() string {
+	if p.goos == "darwin" {
+		return "macos"
+	}
+	return p.goos
 }
 
 func (p *FlutterProvider) GetDefaultInstallPath(version string) string {
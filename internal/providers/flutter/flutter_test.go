@@ -2,6 +2,8 @@ package flutter
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -74,6 +76,7 @@ func TestFlutterProvider_GetLatestVersion(t *testing.T) {
 
 func TestFlutterProvider_GetDownloadURL(t *testing.T) {
 	provider := NewFlutterProvider()
+	provider.goos = "windows"
 	
 	tests := []struct {
 		name    string
@@ -178,3 +181,88 @@ func TestFlutterProvider_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestFlutterProvider_GetChecksum(t *testing.T) {
+	sha256 := strings.Repeat("12", 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases_windows.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"releases":[
+			{"archive":"stable/windows/flutter_windows_3.38.10-stable.zip","sha256":"00"},
+			{"archive":"stable/windows/flutter_windows_3.41.5-stable.zip","sha256":"` + sha256 + `"}
+		]}`))
+	}))
+	defer server.Close()
+
+	provider := NewFlutterProvider()
+	provider.goos = "windows"
+	provider.baseURL = server.URL
+
+	for _, version := range []string{"3.41.5", "latest"} {
+		got, err := provider.GetChecksum(context.Background(), version, "x64")
+		if err != nil {
+			t.Fatalf("GetChecksum(%s) error = %v", version, err)
+		}
+		if got != sha256 {
+			t.Errorf("GetChecksum(%s) = %v, want %v", version, got, sha256)
+		}
+	}
+}
+
+func TestFlutterProvider_GetDownloadURL_Platforms(t *testing.T) {
+	tests := []struct {
+		goos string
+		arch string
+		want string
+	}{
+		{"linux", "amd64", "https://storage.googleapis.com/flutter_infra_release/releases/stable/linux/flutter_linux_3.41.5-stable.tar.xz"},
+		{"darwin", "amd64", "https://storage.googleapis.com/flutter_infra_release/releases/stable/macos/flutter_macos_3.41.5-stable.zip"},
+		{"darwin", "arm64", "https://storage.googleapis.com/flutter_infra_release/releases/stable/macos/flutter_macos_arm64_3.41.5-stable.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.arch, func(t *testing.T) {
+			provider := NewFlutterProvider()
+			provider.goos = tt.goos
+
+			got, err := provider.GetDownloadURL("3.41.5", tt.arch)
+			if err != nil {
+				t.Fatalf("GetDownloadURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlutterProvider_GetChecksum_macOS(t *testing.T) {
+	sha256 := strings.Repeat("34", 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases_macos.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"releases":[
+			{"archive":"stable/macos/flutter_macos_3.41.5-stable.zip","sha256":"00"},
+			{"archive":"stable/macos/flutter_macos_arm64_3.41.5-stable.zip","sha256":"` + sha256 + `"}
+		]}`))
+	}))
+	defer server.Close()
+
+	provider := NewFlutterProvider()
+	provider.baseURL = server.URL
+	provider.goos = "darwin"
+
+	got, err := provider.GetChecksum(context.Background(), "3.41.5", "arm64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if got != sha256 {
+		t.Errorf("GetChecksum() = %v, want %v", got, sha256)
+	}
+}
@@ -4,17 +4,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // GoProvider implements the Provider interface for Go
-type GoProvider struct{}
+type GoProvider struct {
+	baseURL string
+	goos    string
+}
 
 // NewGoProvider creates a new Go provider
 func NewGoProvider() *GoProvider {
-	return &GoProvider{}
+	return &GoProvider{
+		baseURL: "https://go.dev/dl",
+		goos:    runtime.GOOS,
+	}
 }
 
 func (p *GoProvider) Name() string {
@@ -77,18 +86,49 @@ func (p *GoProvider) GetDownloadURL(version string, arch string) (string, error)
 		goArch = "amd64"
 	}
 
-	// Go download URL format: https://go.dev/dl/go{version}.{os}-{arch}.zip
-	baseURL := "https://go.dev/dl"
-	fileName := fmt.Sprintf("go%s.windows-%s.zip", version, goArch)
-	downloadURL := fmt.Sprintf("%s/%s", baseURL, fileName)
+	// Go download URL format: https://go.dev/dl/go{version}.{os}-{arch}.{zip|tar.gz}
+	var fileName string
+	switch p.goos {
+	case "windows":
+		fileName = fmt.Sprintf("go%s.windows-%s.zip", version, goArch)
+	case "linux", "darwin":
+		fileName = fmt.Sprintf("go%s.%s-%s.tar.gz", version, p.goos, goArch)
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", p.goos)
+	}
+	downloadURL := fmt.Sprintf("%s/%s", p.baseURL, fileName)
 
 	return downloadURL, nil
 }
 
-func (p *GoProvider) GetChecksum(version string, arch string) (string, error) {
-	// Checksums would typically be downloaded from Go's SHA256 files
-	// For now, returning empty string (optional verification)
-	return "", nil
+func (p *GoProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+	fileName := path.Base(downloadURL)
+
+	// The go.dev download index lists the SHA-256 of every release file
+	var releases []struct {
+		Version string `json:"version"`
+		Files   []struct {
+			Filename string `json:"filename"`
+			SHA256   string `json:"sha256"`
+		} `json:"files"`
+	}
+	if err := remote.FetchJSON(ctx, p.baseURL+"/?mode=json&include=all", &releases); err != nil {
+		return "", err
+	}
+
+	for _, release := range releases {
+		for _, file := range release.Files {
+			if file.Filename == fileName {
+				return file.SHA256, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s not found", fileName)
 }
 
 func (p *GoProvider) GetDefaultInstallPath(version string) string {
@@ -2,6 +2,8 @@ package golang
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -62,6 +64,7 @@ func TestGoProvider_GetLatestVersion(t *testing.T) {
 
 func TestGoProvider_GetDownloadURL(t *testing.T) {
 	provider := NewGoProvider()
+	provider.goos = "windows"
 	
 	tests := []struct {
 		name    string
@@ -116,6 +119,7 @@ func TestGoProvider_GetDownloadURL(t *testing.T) {
 
 func TestGoProvider_GetDownloadURL_Format(t *testing.T) {
 	provider := NewGoProvider()
+	provider.goos = "windows"
 	
 	url, err := provider.GetDownloadURL("1.26.1", "x64")
 	if err != nil {
@@ -182,3 +186,65 @@ func TestGoProvider_GetDefaultInstallPath(t *testing.T) {
 		t.Errorf("GetDefaultInstallPath() should contain version %v, got %v", version, path)
 	}
 }
+
+func TestGoProvider_GetChecksum(t *testing.T) {
+	sha256 := strings.Repeat("ef", 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"version":"go1.26.1","files":[
+			{"filename":"go1.26.1.linux-amd64.tar.gz","sha256":"00"},
+			{"filename":"go1.26.1.windows-amd64.zip","sha256":"` + sha256 + `"}
+		]}]`))
+	}))
+	defer server.Close()
+
+	provider := NewGoProvider()
+	provider.goos = "windows"
+	provider.baseURL = server.URL
+
+	got, err := provider.GetChecksum(context.Background(), "1.26.1", "amd64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if got != sha256 {
+		t.Errorf("GetChecksum() = %v, want %v", got, sha256)
+	}
+
+	if _, err := provider.GetChecksum(context.Background(), "1.26.1", "arm64"); err == nil {
+		t.Error("GetChecksum() should fail when the file is not in the index")
+	}
+}
+
+func TestGoProvider_GetDownloadURL_Platforms(t *testing.T) {
+	tests := []struct {
+		goos    string
+		arch    string
+		want    string
+		wantErr bool
+	}{
+		{"linux", "amd64", "https://go.dev/dl/go1.26.1.linux-amd64.tar.gz", false},
+		{"linux", "arm64", "https://go.dev/dl/go1.26.1.linux-arm64.tar.gz", false},
+		{"darwin", "arm64", "https://go.dev/dl/go1.26.1.darwin-arm64.tar.gz", false},
+		{"plan9", "amd64", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.arch, func(t *testing.T) {
+			provider := NewGoProvider()
+			provider.goos = tt.goos
+
+			got, err := provider.GetDownloadURL("1.26.1", tt.arch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDownloadURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
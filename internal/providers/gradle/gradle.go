@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // GradleProvider implements the Provider interface for Gradle
-type GradleProvider struct{}
+type GradleProvider struct {
+	baseURL string
+}
 
 // NewGradleProvider creates a new Gradle provider
 func NewGradleProvider() *GradleProvider {
-	return &GradleProvider{}
+	return &GradleProvider{
+		baseURL: "https://services.gradle.org/distributions",
+	}
 }
 
 func (p *GradleProvider) Name() string {
@@ -83,17 +89,20 @@ func (p *GradleProvider) GetLatestVersion(ctx context.Context) (string, error) {
 func (p *GradleProvider) GetDownloadURL(version string, arch string) (string, error) {
 	// Gradle is architecture-independent (pure Java application)
 	// Download from official Gradle distribution site
-	baseURL := "https://services.gradle.org/distributions"
 	fileName := fmt.Sprintf("gradle-%s-bin.zip", version)
-	downloadURL := fmt.Sprintf("%s/%s", baseURL, fileName)
+	downloadURL := fmt.Sprintf("%s/%s", p.baseURL, fileName)
 
 	return downloadURL, nil
 }
 
-func (p *GradleProvider) GetChecksum(version string, arch string) (string, error) {
-	// Checksums would typically be downloaded from Gradle's SHA256 files
-	// For now, returning empty string (optional verification)
-	return "", nil
+func (p *GradleProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// Gradle publishes a ".sha256" file next to every distribution
+	return remote.FetchChecksum(ctx, downloadURL+".sha256", path.Base(downloadURL))
 }
 
 func (p *GradleProvider) GetDefaultInstallPath(version string) string {
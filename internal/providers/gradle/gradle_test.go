@@ -2,6 +2,8 @@ package gradle
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -171,3 +173,27 @@ func TestGradleProvider_GetDefaultInstallPath(t *testing.T) {
 		t.Errorf("GetDefaultInstallPath() should contain version %v, got %v", version, path)
 	}
 }
+
+func TestGradleProvider_GetChecksum(t *testing.T) {
+	sha256 := strings.Repeat("cd", 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gradle-9.4.1-bin.zip.sha256" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(sha256))
+	}))
+	defer server.Close()
+
+	provider := NewGradleProvider()
+	provider.baseURL = server.URL
+
+	got, err := provider.GetChecksum(context.Background(), "9.4.1", "x64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if got != sha256 {
+		t.Errorf("GetChecksum() = %v, want %v", got, sha256)
+	}
+}
@@ -5,16 +5,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // AmazonCorrettoProvider implements the Provider interface for Amazon Corretto
-type AmazonCorrettoProvider struct{}
+type AmazonCorrettoProvider struct {
+	baseURL string
+	goos    string
+}
 
 // NewAmazonCorrettoProvider creates a new Amazon Corretto provider
 func NewAmazonCorrettoProvider() *AmazonCorrettoProvider {
-	return &AmazonCorrettoProvider{}
+	return &AmazonCorrettoProvider{
+		baseURL: "https://corretto.aws/downloads",
+		goos:    runtime.GOOS,
+	}
 }
 
 func (p *AmazonCorrettoProvider) Name() string {
@@ -45,6 +53,26 @@ func (p *AmazonCorrettoProvider) GetLatestVersion(ctx context.Context) (string,
 }
 
 func (p *AmazonCorrettoProvider) GetDownloadURL(version string, arch string) (string, error) {
+	fileName, err := p.fileName(version, arch)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/latest/%s", p.baseURL, fileName), nil
+}
+
+func (p *AmazonCorrettoProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	fileName, err := p.fileName(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// Corretto publishes a SHA-256 sidecar for every "latest" download
+	checksumURL := fmt.Sprintf("%s/latest_sha256/%s", p.baseURL, fileName)
+	return remote.FetchChecksum(ctx, checksumURL, fileName)
+}
+
+// fileName returns the Corretto archive name for a version and architecture
+func (p *AmazonCorrettoProvider) fileName(version string, arch string) (string, error) {
 	// Normalize architecture - Amazon Corretto uses x64, not amd64
 	if arch == "" || arch == "amd64" {
 		arch = "x64"
@@ -54,8 +82,6 @@ func (p *AmazonCorrettoProvider) GetDownloadURL(version string, arch string) (st
 
 	// Map version to download URL
 	// Amazon Corretto uses major version in the filename
-	baseURL := "https://corretto.aws/downloads/latest"
-	
 	var majorVersion string
 	switch version {
 	case "25.0.2", "25":
@@ -72,14 +98,17 @@ func (p *AmazonCorrettoProvider) GetDownloadURL(version string, arch string) (st
 		return "", fmt.Errorf("unsupported version: %s", version)
 	}
 
-	// Format: amazon-corretto-{major}-{arch}-windows-jdk.zip
-	fileName := fmt.Sprintf("amazon-corretto-%s-%s-windows-jdk.zip", majorVersion, arch)
-	return fmt.Sprintf("%s/%s", baseURL, fileName), nil
-}
-
-func (p *AmazonCorrettoProvider) GetChecksum(version string, arch string) (string, error) {
-	// In production, this would fetch the actual checksum from Amazon
-	return "", nil // Checksums would be fetched from provider
+	// Format: amazon-corretto-{major}-{arch}-{os}-jdk.{zip|tar.gz}
+	switch p.goos {
+	case "windows":
+		return fmt.Sprintf("amazon-corretto-%s-%s-windows-jdk.zip", majorVersion, arch), nil
+	case "linux":
+		return fmt.Sprintf("amazon-corretto-%s-%s-linux-jdk.tar.gz", majorVersion, arch), nil
+	case "darwin":
+		return fmt.Sprintf("amazon-corretto-%s-%s-macos-jdk.tar.gz", majorVersion, arch), nil
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", p.goos)
+	}
 }
 
 func (p *AmazonCorrettoProvider) GetDefaultInstallPath(version string) string {
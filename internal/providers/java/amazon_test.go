@@ -62,6 +62,7 @@ func TestAmazonCorrettoProvider_GetLatestVersion(t *testing.T) {
 
 func TestAmazonCorrettoProvider_GetDownloadURL(t *testing.T) {
 	provider := NewAmazonCorrettoProvider()
+	provider.goos = "windows"
 	
 	tests := []struct {
 		name    string
@@ -182,3 +183,33 @@ func TestAmazonCorrettoProvider_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAmazonCorrettoProvider_GetDownloadURL_Platforms(t *testing.T) {
+	tests := []struct {
+		goos    string
+		arch    string
+		want    string
+		wantErr bool
+	}{
+		{"linux", "amd64", "https://corretto.aws/downloads/latest/amazon-corretto-21-x64-linux-jdk.tar.gz", false},
+		{"linux", "arm64", "https://corretto.aws/downloads/latest/amazon-corretto-21-aarch64-linux-jdk.tar.gz", false},
+		{"darwin", "arm64", "https://corretto.aws/downloads/latest/amazon-corretto-21-aarch64-macos-jdk.tar.gz", false},
+		{"plan9", "amd64", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.arch, func(t *testing.T) {
+			provider := NewAmazonCorrettoProvider()
+			provider.goos = tt.goos
+
+			got, err := provider.GetDownloadURL("21", tt.arch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDownloadURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // GraalVMProvider implements the Provider interface for GraalVM
-type GraalVMProvider struct{}
+type GraalVMProvider struct {
+	goos string
+}
 
 // NewGraalVMProvider creates a new GraalVM provider
 func NewGraalVMProvider() *GraalVMProvider {
-	return &GraalVMProvider{}
+	return &GraalVMProvider{
+		goos: runtime.GOOS,
+	}
 }
 
 func (p *GraalVMProvider) Name() string {
@@ -70,9 +77,19 @@ func (p *GraalVMProvider) GetDownloadURL(version string, arch string) (string, e
 		return "", fmt.Errorf("unsupported version: %s", version)
 	}
 
-	// Format: graalvm-community-jdk-{graalVersion}_windows-{arch}_bin.zip
+	// Format: graalvm-community-jdk-{graalVersion}_{os}-{arch}_bin.{zip|tar.gz}
 	// Example: https://github.com/graalvm/graalvm-ce-builds/releases/download/jdk-25.0.2/graalvm-community-jdk-25.0.2_windows-x64_bin.zip
-	fileName := fmt.Sprintf("graalvm-community-jdk-%s_windows-%s_bin.zip", graalVersion, arch)
+	var fileName string
+	switch p.goos {
+	case "windows":
+		fileName = fmt.Sprintf("graalvm-community-jdk-%s_windows-%s_bin.zip", graalVersion, arch)
+	case "linux":
+		fileName = fmt.Sprintf("graalvm-community-jdk-%s_linux-%s_bin.tar.gz", graalVersion, arch)
+	case "darwin":
+		fileName = fmt.Sprintf("graalvm-community-jdk-%s_macos-%s_bin.tar.gz", graalVersion, arch)
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", p.goos)
+	}
 	
 	// GraalVM uses a tag format like "jdk-25.0.2"
 	releaseTag := fmt.Sprintf("jdk-%s", graalVersion)
@@ -80,9 +97,14 @@ func (p *GraalVMProvider) GetDownloadURL(version string, arch string) (string, e
 	return fmt.Sprintf("https://github.com/graalvm/graalvm-ce-builds/releases/download/%s/%s", releaseTag, fileName), nil
 }
 
-func (p *GraalVMProvider) GetChecksum(version string, arch string) (string, error) {
-	// In production, this would fetch the actual checksum from GraalVM releases
-	return "", nil // Checksums would be fetched from provider
+func (p *GraalVMProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// Every GraalVM CE release asset has a ".sha256" sidecar next to it
+	return remote.FetchChecksum(ctx, downloadURL+".sha256", path.Base(downloadURL))
 }
 
 func (p *GraalVMProvider) GetDefaultInstallPath(version string) string {
@@ -62,6 +62,7 @@ func TestGraalVMProvider_GetLatestVersion(t *testing.T) {
 
 func TestGraalVMProvider_GetDownloadURL(t *testing.T) {
 	provider := NewGraalVMProvider()
+	provider.goos = "windows"
 	
 	tests := []struct {
 		name    string
@@ -175,3 +176,29 @@ func TestGraalVMProvider_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestGraalVMProvider_GetDownloadURL_Platforms(t *testing.T) {
+	tests := []struct {
+		goos string
+		arch string
+		want string
+	}{
+		{"linux", "amd64", "https://github.com/graalvm/graalvm-ce-builds/releases/download/jdk-21.0.2/graalvm-community-jdk-21.0.2_linux-x64_bin.tar.gz"},
+		{"darwin", "arm64", "https://github.com/graalvm/graalvm-ce-builds/releases/download/jdk-21.0.2/graalvm-community-jdk-21.0.2_macos-aarch64_bin.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.arch, func(t *testing.T) {
+			provider := NewGraalVMProvider()
+			provider.goos = tt.goos
+
+			got, err := provider.GetDownloadURL("21", tt.arch)
+			if err != nil {
+				t.Fatalf("GetDownloadURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,18 +3,83 @@ package java
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
+	"github.com/javaquery/unosdk/internal/registry"
 	"github.com/javaquery/unosdk/pkg/models"
+	"github.com/javaquery/unosdk/pkg/utils"
+	"go.uber.org/zap"
 )
 
-// OpenJDKProvider implements the Provider interface for OpenJDK
-type OpenJDKProvider struct{}
+// adoptiumPageSize is the number of releases requested per page when
+// searching the Adoptium API for a specific version
+const adoptiumPageSize = 50
+
+// adoptiumMaxPages bounds how far back a version search goes
+const adoptiumMaxPages = 10
+
+// OpenJDKProvider implements the Provider interface for OpenJDK (Eclipse
+// Temurin), discovering releases through the Adoptium v3 API
+type OpenJDKProvider struct {
+	apiURL string
+	goos   string
+	// arch is the architecture versions are listed for
+	arch   string
+	cache  *registry.Cache
+	logger *zap.Logger
+}
 
 // NewOpenJDKProvider creates a new OpenJDK provider
 func NewOpenJDKProvider() *OpenJDKProvider {
-	return &OpenJDKProvider{}
+	return &OpenJDKProvider{
+		apiURL: "https://api.adoptium.net",
+		goos:   runtime.GOOS,
+		arch:   runtime.GOARCH,
+		cache:  registry.NewDefaultCache(),
+		logger: utils.NewLogger(),
+	}
+}
+
+// adoptiumVersion is the version_data object of the Adoptium API
+type adoptiumVersion struct {
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+	Security int `json:"security"`
+	Patch    int `json:"patch"`
+	Build    int `json:"build"`
+}
+
+// String formats the version the way unosdk names Java versions ("21.0.10", "8u392")
+func (v adoptiumVersion) String() string {
+	if v.Major == 8 {
+		return fmt.Sprintf("8u%d", v.Security)
+	}
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Security)
+	if v.Patch > 0 {
+		version += fmt.Sprintf(".%d", v.Patch)
+	}
+	return version
+}
+
+// adoptiumPackage is the package object of an Adoptium binary
+type adoptiumPackage struct {
+	Name     string `json:"name"`
+	Link     string `json:"link"`
+	Checksum string `json:"checksum"`
+}
+
+// adoptiumAsset is the resolved download of a single release
+type adoptiumAsset struct {
+	Version     string          `json:"version"`
+	ReleaseName string          `json:"release_name"`
+	Package     adoptiumPackage `json:"package"`
 }
 
 func (p *OpenJDKProvider) Name() string {
@@ -29,64 +94,228 @@ func (p *OpenJDKProvider) Type() models.SDKType {
 	return models.JavaSDK
 }
 
+// GetVersions returns the latest GA build of every available feature release
+// with a build for this platform. Releases that cannot be resolved are skipped.
 func (p *OpenJDKProvider) GetVersions(ctx context.Context) ([]string, error) {
-	return []string{
-		"25.0.2",
-		"21.0.10",
-		"17.0.18",
-		"11.0.21",
-		"8u392",
-	}, nil
+	releases, err := p.availableReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(releases.AvailableReleases))
+	var lastErr error
+	for _, major := range releases.AvailableReleases {
+		asset, err := p.latestAsset(ctx, major, p.arch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			p.logger.Warn("Skipping OpenJDK release", zap.Int("release", major), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		versions = append(versions, asset.Version)
+	}
+
+	if len(versions) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return versions, nil
 }
 
+// GetLatestVersion returns the latest GA build of the most recent feature release
 func (p *OpenJDKProvider) GetLatestVersion(ctx context.Context) (string, error) {
-	return "25.0.2", nil
+	releases, err := p.availableReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	asset, err := p.latestAsset(ctx, releases.MostRecentFeatureRelease, p.arch)
+	if err != nil {
+		return "", err
+	}
+	return asset.Version, nil
 }
 
 func (p *OpenJDKProvider) GetDownloadURL(version string, arch string) (string, error) {
-	// Map common architecture names to Adoptium's naming convention
+	return p.ResolveDownloadURL(context.Background(), version, arch)
+}
+
+// ResolveDownloadURL looks the download URL up in the Adoptium API with the
+// caller's context
+func (p *OpenJDKProvider) ResolveDownloadURL(ctx context.Context, version string, arch string) (string, error) {
+	asset, err := p.asset(ctx, version, arch)
+	if err != nil {
+		return "", err
+	}
+	return asset.Package.Link, nil
+}
+
+func (p *OpenJDKProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	asset, err := p.asset(ctx, version, arch)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(asset.Package.Checksum), nil
+}
+
+// availableReleases holds the feature releases published by Adoptium
+type availableReleases struct {
+	AvailableReleases        []int `json:"available_releases"`
+	MostRecentFeatureRelease int   `json:"most_recent_feature_release"`
+}
+
+// availableReleases returns the feature releases, newest first
+func (p *OpenJDKProvider) availableReleases(ctx context.Context) (*availableReleases, error) {
+	var releases availableReleases
+	if p.cache.Load("openjdk_available_releases", &releases) {
+		return &releases, nil
+	}
+
+	if err := remote.FetchJSON(ctx, p.apiURL+"/v3/info/available_releases", &releases); err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenJDK releases: %w", err)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(releases.AvailableReleases)))
+
+	p.cache.Set("openjdk_available_releases", releases)
+	return &releases, nil
+}
+
+// asset resolves a version ("21" for the latest GA build of a feature
+// release, or an exact version like "21.0.10" or "8u392") to its download
+func (p *OpenJDKProvider) asset(ctx context.Context, version string, arch string) (*adoptiumAsset, error) {
+	if major, err := strconv.Atoi(version); err == nil {
+		return p.latestAsset(ctx, major, arch)
+	}
+
+	major, err := featureRelease(version)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("openjdk_%s_%s_%s", version, p.adoptiumOS(), adoptiumArch(arch))
+	var asset adoptiumAsset
+	if p.cache.Load(key, &asset) {
+		return &asset, nil
+	}
+
+	var releases []struct {
+		ReleaseName string          `json:"release_name"`
+		VersionData adoptiumVersion `json:"version_data"`
+		Binaries    []struct {
+			Package adoptiumPackage `json:"package"`
+		} `json:"binaries"`
+	}
+
+	for page := 0; page < adoptiumMaxPages; page++ {
+		apiURL := fmt.Sprintf("%s/v3/assets/feature_releases/%d/ga?%s", p.apiURL, major,
+			p.query(arch, url.Values{
+				"page":       {strconv.Itoa(page)},
+				"page_size":  {strconv.Itoa(adoptiumPageSize)},
+				"sort_order": {"DESC"},
+			}))
+		releases = releases[:0]
+		if err := remote.FetchJSON(ctx, apiURL, &releases); err != nil {
+			return nil, fmt.Errorf("unsupported version: %s: %w", version, err)
+		}
+
+		for _, release := range releases {
+			if release.VersionData.String() != version || len(release.Binaries) == 0 {
+				continue
+			}
+			asset = adoptiumAsset{
+				Version:     version,
+				ReleaseName: release.ReleaseName,
+				Package:     release.Binaries[0].Package,
+			}
+			p.cache.Set(key, asset)
+			return &asset, nil
+		}
+
+		if len(releases) < adoptiumPageSize {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported version: %s", version)
+}
+
+// latestAsset returns the latest GA build of a feature release
+func (p *OpenJDKProvider) latestAsset(ctx context.Context, major int, arch string) (*adoptiumAsset, error) {
+	key := fmt.Sprintf("openjdk_latest_%d_%s_%s", major, p.adoptiumOS(), adoptiumArch(arch))
+	var asset adoptiumAsset
+	if p.cache.Load(key, &asset) {
+		return &asset, nil
+	}
+
+	var assets []struct {
+		ReleaseName string          `json:"release_name"`
+		Version     adoptiumVersion `json:"version"`
+		Binary      struct {
+			Package adoptiumPackage `json:"package"`
+		} `json:"binary"`
+	}
+
+	apiURL := fmt.Sprintf("%s/v3/assets/latest/%d/hotspot?%s", p.apiURL, major, p.query(arch, url.Values{}))
+	if err := remote.FetchJSON(ctx, apiURL, &assets); err != nil {
+		return nil, fmt.Errorf("unsupported version: %d: %w", major, err)
+	}
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("unsupported version: %d", major)
+	}
+
+	asset = adoptiumAsset{
+		Version:     assets[0].Version.String(),
+		ReleaseName: assets[0].ReleaseName,
+		Package:     assets[0].Binary.Package,
+	}
+	p.cache.Set(key, asset)
+	return &asset, nil
+}
+
+// query adds the platform filters shared by all asset requests
+func (p *OpenJDKProvider) query(arch string, values url.Values) string {
+	values.Set("architecture", adoptiumArch(arch))
+	values.Set("image_type", "jdk")
+	values.Set("os", p.adoptiumOS())
+	values.Set("vendor", "eclipse")
+	return values.Encode()
+}
+
+// adoptiumOS maps the target operating system to Adoptium's naming convention
+func (p *OpenJDKProvider) adoptiumOS() string {
+	if p.goos == "darwin" {
+		return "mac"
+	}
+	return p.goos
+}
+
+// adoptiumArch maps common architecture names to Adoptium's naming convention
+func adoptiumArch(arch string) string {
 	switch arch {
 	case "", "amd64", "x86_64":
-		arch = "x64"
+		return "x64"
 	case "x86", "i386":
-		arch = "x86-32"
+		return "x32"
 	case "arm64", "aarch64":
-		arch = "aarch64"
-	}
-
-	// OpenJDK download URLs (using Adoptium/Eclipse Temurin)
-	var repoName, releaseTag, fileName string
-	
-	switch version {
-	case "25.0.2", "25":
-		repoName = "temurin25-binaries"
-		releaseTag = "jdk-25.0.2+10"
-		fileName = fmt.Sprintf("OpenJDK25U-jdk_%s_windows_hotspot_25.0.2_10.zip", arch)
-	case "21.0.10", "21":
-		repoName = "temurin21-binaries"
-		releaseTag = "jdk-21.0.10+7"
-		fileName = fmt.Sprintf("OpenJDK21U-jdk_%s_windows_hotspot_21.0.10_7.zip", arch)
-	case "17.0.18", "17":
-		repoName = "temurin17-binaries"
-		releaseTag = "jdk-17.0.18+8"
-		fileName = fmt.Sprintf("OpenJDK17U-jdk_%s_windows_hotspot_17.0.18_8.zip", arch)
-	case "11.0.21", "11":
-		repoName = "temurin11-binaries"
-		releaseTag = "jdk-11.0.21+9"
-		fileName = fmt.Sprintf("OpenJDK11U-jdk_%s_windows_hotspot_11.0.21_9.zip", arch)
-	case "8u392", "8":
-		repoName = "temurin8-binaries"
-		releaseTag = "jdk8u392-b08"
-		fileName = fmt.Sprintf("OpenJDK8U-jdk_%s_windows_hotspot_8u392b08.zip", arch)
+		return "aarch64"
 	default:
-		return "", fmt.Errorf("unsupported version: %s", version)
+		return arch
 	}
-
-	return fmt.Sprintf("https://github.com/adoptium/%s/releases/download/%s/%s", repoName, releaseTag, fileName), nil
 }
 
-func (p *OpenJDKProvider) GetChecksum(version string, arch string) (string, error) {
-	return "", nil
+// featureRelease extracts the feature release number from a version like
+// "21.0.10" or "8u392"
+func featureRelease(version string) (int, error) {
+	end := strings.IndexAny(version, ".u")
+	if end < 0 {
+		end = len(version)
+	}
+	major, err := strconv.Atoi(version[:end])
+	if err != nil {
+		return 0, fmt.Errorf("unsupported version: %s", version)
+	}
+	return major, nil
 }
 
 func (p *OpenJDKProvider) GetDefaultInstallPath(version string) string {
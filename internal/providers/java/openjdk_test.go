@@ -2,8 +2,19 @@ package java
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"go.uber.org/zap"
 )
 
 func TestOpenJDKProvider_Name(t *testing.T) {
@@ -20,49 +31,173 @@ func TestOpenJDKProvider_DisplayName(t *testing.T) {
 	}
 }
 
-func TestOpenJDKProvider_GetVersions(t *testing.T) {
+// temurinRelease describes a release served by newAdoptiumServer
+type temurinRelease struct {
+	name    string
+	version adoptiumVersion
+	file    string // file name with %s for the architecture
+	x64Only bool   // no builds for other architectures
+}
+
+// temurinReleases is the fake release history, newest first per feature release
+var temurinReleases = map[int][]temurinRelease{
+	25: {
+		{"jdk-25.0.2+10", adoptiumVersion{Major: 25, Security: 2, Build: 10}, "OpenJDK25U-jdk_%s_windows_hotspot_25.0.2_10.zip", false},
+	},
+	21: {
+		{"jdk-21.0.10+7", adoptiumVersion{Major: 21, Security: 10, Build: 7}, "OpenJDK21U-jdk_%s_windows_hotspot_21.0.10_7.zip", false},
+		{"jdk-21.0.9+10", adoptiumVersion{Major: 21, Security: 9, Build: 10}, "OpenJDK21U-jdk_%s_windows_hotspot_21.0.9_10.zip", false},
+	},
+	17: {
+		{"jdk-17.0.18+8", adoptiumVersion{Major: 17, Security: 18, Build: 8}, "OpenJDK17U-jdk_%s_windows_hotspot_17.0.18_8.zip", true},
+	},
+	8: {
+		{"jdk8u392-b08", adoptiumVersion{Major: 8, Security: 392, Build: 8}, "OpenJDK8U-jdk_%s_windows_hotspot_8u392b08.zip", false},
+	},
+}
+
+// newAdoptiumServer starts an httptest stand-in for the Adoptium v3 API and
+// returns a provider using it together with a counter of served requests
+func newAdoptiumServer(t *testing.T) (*OpenJDKProvider, *int) {
+	t.Helper()
+	requests := 0
+
+	pkg := func(release temurinRelease, arch string) map[string]string {
+		name := fmt.Sprintf(release.file, arch)
+		return map[string]string{
+			"name":     name,
+			"link":     fmt.Sprintf("https://github.com/adoptium/temurin%d-binaries/releases/download/%s/%s", release.version.Major, release.name, name),
+			"checksum": fmt.Sprintf("%x", sha256.Sum256([]byte(name))),
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if r.URL.Path != "/v3/info/available_releases" &&
+			(query.Get("os") != "windows" || query.Get("image_type") != "jdk" || query.Get("vendor") != "eclipse") {
+			http.Error(w, "missing filters", http.StatusBadRequest)
+			return
+		}
+		arch := query.Get("architecture")
+
+		var major int
+		var body interface{}
+		switch {
+		case r.URL.Path == "/v3/info/available_releases":
+			body = map[string]interface{}{
+				// 11 is not served at all
+				"available_releases":          []int{8, 11, 17, 21, 25},
+				"most_recent_feature_release": 25,
+			}
+		case matchPath(r.URL.Path, "/v3/assets/latest/%d/hotspot", &major):
+			releases, ok := temurinReleases[major]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			if releases[0].x64Only && arch != "x64" {
+				body = []interface{}{}
+				break
+			}
+			body = []interface{}{map[string]interface{}{
+				"release_name": releases[0].name,
+				"version":      releases[0].version,
+				"binary":       map[string]interface{}{"package": pkg(releases[0], arch)},
+			}}
+		case matchPath(r.URL.Path, "/v3/assets/feature_releases/%d/ga", &major):
+			releases, ok := temurinReleases[major]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			var list []interface{}
+			for _, release := range releases {
+				list = append(list, map[string]interface{}{
+					"release_name": release.name,
+					"version_data": release.version,
+					"binaries":     []interface{}{map[string]interface{}{"package": pkg(release, arch)}},
+				})
+			}
+			body = list
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
 	provider := NewOpenJDKProvider()
-	ctx := context.Background()
-	
-	versions, err := provider.GetVersions(ctx)
-	if err != nil {
-		t.Fatalf("GetVersions() error = %v", err)
+	provider.apiURL = server.URL
+	provider.goos = "windows"
+	provider.arch = "amd64"
+	provider.cache = registry.NewCache(t.TempDir(), time.Hour)
+	provider.logger = zap.NewNop()
+	return provider, &requests
+}
+
+// matchPath reports whether path matches format, scanning its integer into major
+func matchPath(path, format string, major *int) bool {
+	_, err := fmt.Sscanf(path, format, major)
+	return err == nil && fmt.Sprintf(format, *major) == path
+}
+
+func TestOpenJDKProvider_GetVersions(t *testing.T) {
+	tests := []struct {
+		arch string
+		want []string
+	}{
+		{"amd64", []string{"25.0.2", "21.0.10", "17.0.18", "8u392"}},
+		// 17 has no arm64 build, 11 fails on every platform
+		{"arm64", []string{"25.0.2", "21.0.10", "8u392"}},
 	}
-	
-	if len(versions) == 0 {
-		t.Error("GetVersions() returned empty list")
+
+	for _, tt := range tests {
+		t.Run(tt.arch, func(t *testing.T) {
+			provider, _ := newAdoptiumServer(t)
+			provider.arch = tt.arch
+
+			versions, err := provider.GetVersions(context.Background())
+			if err != nil {
+				t.Fatalf("GetVersions() error = %v", err)
+			}
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("GetVersions() = %v, want %v", versions, tt.want)
+			}
+		})
 	}
-	
-	// Check if latest version is present
-	found := false
-	for _, v := range versions {
-		if v == "25.0.2" {
-			found = true
-			break
-		}
+}
+
+func TestOpenJDKProvider_ResolveDownloadURL_Cancelled(t *testing.T) {
+	provider, requests := newAdoptiumServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := provider.ResolveDownloadURL(ctx, "21.0.10", "x64"); err == nil {
+		t.Error("ResolveDownloadURL() with a cancelled context should fail")
 	}
-	if !found {
-		t.Error("GetVersions() should contain version 25.0.2")
+	if *requests != 0 {
+		t.Errorf("ResolveDownloadURL() with a cancelled context made %d requests", *requests)
 	}
 }
 
 func TestOpenJDKProvider_GetLatestVersion(t *testing.T) {
-	provider := NewOpenJDKProvider()
-	ctx := context.Background()
-	
-	version, err := provider.GetLatestVersion(ctx)
+	provider, _ := newAdoptiumServer(t)
+
+	version, err := provider.GetLatestVersion(context.Background())
 	if err != nil {
 		t.Fatalf("GetLatestVersion() error = %v", err)
 	}
-	
+
 	if version != "25.0.2" {
 		t.Errorf("GetLatestVersion() = %v, want %v", version, "25.0.2")
 	}
 }
 
 func TestOpenJDKProvider_GetDownloadURL(t *testing.T) {
-	provider := NewOpenJDKProvider()
-	
+	provider, _ := newAdoptiumServer(t)
+
 	tests := []struct {
 		name    string
 		version string
@@ -85,17 +220,17 @@ func TestOpenJDKProvider_GetDownloadURL(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "JDK 17 x64 architecture",
-			version: "17.0.18",
+			name:    "older JDK 21 patch release",
+			version: "21.0.9",
 			arch:    "x64",
-			want:    "https://github.com/adoptium/temurin17-binaries/releases/download/jdk-17.0.18+8/OpenJDK17U-jdk_x64_windows_hotspot_17.0.18_8.zip",
+			want:    "https://github.com/adoptium/temurin21-binaries/releases/download/jdk-21.0.9+10/OpenJDK21U-jdk_x64_windows_hotspot_21.0.9_10.zip",
 			wantErr: false,
 		},
 		{
-			name:    "JDK 11 x64 architecture",
-			version: "11.0.21",
+			name:    "major version resolves to latest GA build",
+			version: "21",
 			arch:    "x64",
-			want:    "https://github.com/adoptium/temurin11-binaries/releases/download/jdk-11.0.21+9/OpenJDK11U-jdk_x64_windows_hotspot_11.0.21_9.zip",
+			want:    "https://github.com/adoptium/temurin21-binaries/releases/download/jdk-21.0.10+7/OpenJDK21U-jdk_x64_windows_hotspot_21.0.10_7.zip",
 			wantErr: false,
 		},
 		{
@@ -112,6 +247,13 @@ func TestOpenJDKProvider_GetDownloadURL(t *testing.T) {
 			want:    "https://github.com/adoptium/temurin21-binaries/releases/download/jdk-21.0.10+7/OpenJDK21U-jdk_aarch64_windows_hotspot_21.0.10_7.zip",
 			wantErr: false,
 		},
+		{
+			name:    "unknown patch release",
+			version: "21.0.99",
+			arch:    "x64",
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name:    "unsupported version",
 			version: "99.0.0",
@@ -119,8 +261,15 @@ func TestOpenJDKProvider_GetDownloadURL(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name:    "invalid version",
+			version: "latest-ish",
+			arch:    "x64",
+			want:    "",
+			wantErr: true,
+		},
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := provider.GetDownloadURL(tt.version, tt.arch)
@@ -135,6 +284,38 @@ func TestOpenJDKProvider_GetDownloadURL(t *testing.T) {
 	}
 }
 
+func TestOpenJDKProvider_Cache(t *testing.T) {
+	provider, requests := newAdoptiumServer(t)
+	ctx := context.Background()
+
+	first, err := provider.GetVersions(ctx)
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	served := *requests
+
+	second, err := provider.GetVersions(ctx)
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("cached GetVersions() = %v, want %v", second, first)
+	}
+	// Only the release that failed is looked up again
+	if *requests != served+1 {
+		t.Errorf("cached GetVersions() made %d requests, want 1", *requests-served)
+	}
+
+	// An expired cache goes back to the API
+	provider.cache = registry.NewCache(t.TempDir(), 0)
+	if _, err := provider.GetVersions(ctx); err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	if *requests == served {
+		t.Error("GetVersions() with an expired cache should query the API")
+	}
+}
+
 func TestOpenJDKProvider_GetDefaultInstallPath(t *testing.T) {
 	provider := NewOpenJDKProvider()
 	version := "21.0.1"
@@ -182,3 +363,41 @@ func TestOpenJDKProvider_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenJDKProvider_GetChecksum(t *testing.T) {
+	provider, _ := newAdoptiumServer(t)
+	want := fmt.Sprintf("%x", sha256.Sum256([]byte("OpenJDK21U-jdk_x64_windows_hotspot_21.0.10_7.zip")))
+
+	for _, version := range []string{"21", "21.0.10"} {
+		got, err := provider.GetChecksum(context.Background(), version, "amd64")
+		if err != nil {
+			t.Fatalf("GetChecksum(%v) error = %v", version, err)
+		}
+		if got != want {
+			t.Errorf("GetChecksum(%v) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestOpenJDKProvider_Query(t *testing.T) {
+	tests := []struct {
+		goos string
+		arch string
+		want string
+	}{
+		{"windows", "amd64", "architecture=x64&image_type=jdk&os=windows&vendor=eclipse"},
+		{"linux", "arm64", "architecture=aarch64&image_type=jdk&os=linux&vendor=eclipse"},
+		{"darwin", "arm64", "architecture=aarch64&image_type=jdk&os=mac&vendor=eclipse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.arch, func(t *testing.T) {
+			provider := NewOpenJDKProvider()
+			provider.goos = tt.goos
+
+			if got := provider.query(tt.arch, url.Values{}); got != tt.want {
+				t.Errorf("query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
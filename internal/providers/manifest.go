@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// manifestProvider serves versions, download URLs and checksums from a synced
+// registry manifest, falling back to the wrapped provider for anything the
+// manifest does not list
+type manifestProvider struct {
+	Provider
+	entry *registry.ManifestProvider
+	goos  string
+}
+
+// ApplyManifest wraps every provider listed in the manifest so that the
+// manifest takes precedence over the provider's compiled-in data
+func ApplyManifest(r *Registry, manifest *registry.Manifest) {
+	if manifest == nil {
+		return
+	}
+
+	for _, provider := range r.ListAll() {
+		entry, ok := manifest.Provider(string(provider.Type()), provider.Name())
+		if !ok || len(entry.Versions) == 0 {
+			continue
+		}
+		r.Register(&manifestProvider{Provider: provider, entry: entry, goos: runtime.GOOS})
+	}
+}
+
+// GetVersions returns the manifest versions available for this OS, newest first
+func (p *manifestProvider) GetVersions(ctx context.Context) ([]string, error) {
+	var versions []string
+	for _, v := range p.entry.Versions {
+		for _, download := range v.Downloads {
+			if download.OS == p.goos {
+				versions = append(versions, v.Version)
+				break
+			}
+		}
+	}
+
+	if len(versions) == 0 {
+		return p.Provider.GetVersions(ctx)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return models.CompareVersions(versions[i], versions[j]) > 0
+	})
+	return versions, nil
+}
+
+func (p *manifestProvider) GetLatestVersion(ctx context.Context) (string, error) {
+	versions, err := p.GetVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions available for %s", p.Name())
+	}
+	return versions[0], nil
+}
+
+// ResolveVersion delegates aliases to the wrapped provider, resolving
+// "latest" from the manifest otherwise
+func (p *manifestProvider) ResolveVersion(ctx context.Context, version string) (string, error) {
+	if resolver, ok := p.Provider.(VersionResolver); ok {
+		return resolver.ResolveVersion(ctx, version)
+	}
+	if version == "latest" {
+		return p.GetLatestVersion(ctx)
+	}
+	return version, nil
+}
+
+func (p *manifestProvider) GetDownloadURL(version string, arch string) (string, error) {
+	if download, ok := p.entry.Download(version, p.goos, hostArch(arch)); ok {
+		return download.URL, nil
+	}
+	return p.Provider.GetDownloadURL(version, arch)
+}
+
+// ResolveDownloadURL looks the download URL up in the manifest, falling back
+// to the wrapped provider with the caller's context
+func (p *manifestProvider) ResolveDownloadURL(ctx context.Context, version string, arch string) (string, error) {
+	if download, ok := p.entry.Download(version, p.goos, hostArch(arch)); ok {
+		return download.URL, nil
+	}
+	return DownloadURL(ctx, p.Provider, version, arch)
+}
+
+func (p *manifestProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	if download, ok := p.entry.Download(version, p.goos, hostArch(arch)); ok && download.Checksum != "" {
+		return download.Checksum, nil
+	}
+	return p.Provider.GetChecksum(ctx, version, arch)
+}
+
+// EnvSpec exposes the env rules of the wrapped provider, if it declares any
+func (p *manifestProvider) EnvSpec() models.EnvSpec {
+	if envProvider, ok := p.Provider.(EnvProvider); ok {
+		return envProvider.EnvSpec()
+	}
+	return models.EnvSpec{}
+}
+
+// hostArch defaults an empty architecture to the host's
+func hostArch(arch string) string {
+	if arch == "" {
+		return runtime.GOARCH
+	}
+	return arch
+}
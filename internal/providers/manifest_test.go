@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/internal/registry"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestApplyManifest(t *testing.T) {
+	manifest, err := registry.ParseManifest([]byte(`{"providers": [
+		{"type": "java", "name": "test", "versions": [
+			{"version": "21.0.9", "downloads": [{"os": "windows", "arch": "amd64", "url": "https://mirror/21.0.9.zip"}]},
+			{"version": "21.0.11", "downloads": [{"os": "windows", "arch": "x64", "url": "https://mirror/21.0.11.zip", "checksum": "sha256:aa"}]},
+			{"version": "22.0.1", "downloads": [{"os": "linux", "arch": "x64", "url": "https://mirror/22.0.1.tar.gz"}]}
+		]}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+
+	catalog := NewRegistry()
+	catalog.Register(&mockProvider{name: "test", sdkType: models.JavaSDK, versions: []string{"17.0.1"}, latestVer: "17.0.1"})
+	catalog.Register(&mockProvider{name: "other", sdkType: models.NodeSDK})
+	ApplyManifest(catalog, manifest)
+
+	provider, _ := catalog.Get(models.JavaSDK, "test")
+	if wrapped, ok := provider.(*manifestProvider); !ok {
+		t.Fatal("ApplyManifest() should wrap listed providers")
+	} else {
+		wrapped.goos = "windows"
+	}
+	if other, _ := catalog.Get(models.NodeSDK, "other"); reflect.TypeOf(other) != reflect.TypeOf(&mockProvider{}) {
+		t.Error("ApplyManifest() should leave unlisted providers alone")
+	}
+
+	ctx := context.Background()
+	versions, _ := provider.GetVersions(ctx)
+	if want := []string{"21.0.11", "21.0.9"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("GetVersions() = %v, want %v", versions, want)
+	}
+
+	latest, _ := ResolveVersion(ctx, provider, "latest")
+	if latest != "21.0.11" {
+		t.Errorf("ResolveVersion(latest) = %v, want %v", latest, "21.0.11")
+	}
+
+	url, _ := provider.GetDownloadURL("21.0.11", "amd64")
+	if url != "https://mirror/21.0.11.zip" {
+		t.Errorf("GetDownloadURL() = %v, want manifest URL", url)
+	}
+	checksum, _ := provider.GetChecksum(ctx, "21.0.11", "amd64")
+	if checksum != "sha256:aa" {
+		t.Errorf("GetChecksum() = %v, want manifest checksum", checksum)
+	}
+
+	// Versions missing from the manifest fall back to the provider
+	url, _ = provider.GetDownloadURL("17.0.1", "amd64")
+	if url != "https://example.com/17.0.1" {
+		t.Errorf("GetDownloadURL() = %v, want provider URL", url)
+	}
+	checksum, _ = provider.GetChecksum(ctx, "21.0.9", "amd64")
+	if checksum != "checksum123" {
+		t.Errorf("GetChecksum() = %v, want provider checksum", checksum)
+	}
+}
@@ -4,17 +4,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // MavenProvider implements the Provider interface for Apache Maven
-type MavenProvider struct{}
+type MavenProvider struct {
+	baseURL string
+	goos    string
+}
 
 // NewMavenProvider creates a new Maven provider
 func NewMavenProvider() *MavenProvider {
-	return &MavenProvider{}
+	return &MavenProvider{
+		baseURL: "https://archive.apache.org/dist/maven/maven-3",
+		goos:    runtime.GOOS,
+	}
 }
 
 func (p *MavenProvider) Name() string {
@@ -49,7 +58,6 @@ func (p *MavenProvider) GetLatestVersion(ctx context.Context) (string, error) {
 func (p *MavenProvider) GetDownloadURL(version string, arch string) (string, error) {
 	// Maven is architecture-independent (pure Java application)
 	// Download from Apache archive
-	baseURL := "https://archive.apache.org/dist/maven/maven-3"
 	
 	// Extract major.minor version for URL path
 	var majorMinor string
@@ -64,16 +72,24 @@ func (p *MavenProvider) GetDownloadURL(version string, arch string) (string, err
 		return "", fmt.Errorf("unsupported version: %s", version)
 	}
 
+	// The tarball keeps the executable bit of the bin/mvn script on Unix
 	fileName := fmt.Sprintf("apache-maven-%s-bin.zip", majorMinor)
-	downloadURL := fmt.Sprintf("%s/%s/binaries/%s", baseURL, majorMinor, fileName)
+	if p.goos != "windows" {
+		fileName = fmt.Sprintf("apache-maven-%s-bin.tar.gz", majorMinor)
+	}
+	downloadURL := fmt.Sprintf("%s/%s/binaries/%s", p.baseURL, majorMinor, fileName)
 
 	return downloadURL, nil
 }
 
-func (p *MavenProvider) GetChecksum(version string, arch string) (string, error) {
-	// Checksums would typically be downloaded from Apache Maven's SHA512 files
-	// For now, returning empty string (optional verification)
-	return "", nil
+func (p *MavenProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// Apache publishes a ".sha512" file next to every binary distribution
+	return remote.FetchChecksum(ctx, downloadURL+".sha512", path.Base(downloadURL))
 }
 
 func (p *MavenProvider) GetDefaultInstallPath(version string) string {
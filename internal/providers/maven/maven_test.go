@@ -2,6 +2,8 @@ package maven
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -62,6 +64,7 @@ func TestMavenProvider_GetLatestVersion(t *testing.T) {
 
 func TestMavenProvider_GetDownloadURL(t *testing.T) {
 	provider := NewMavenProvider()
+	provider.goos = "windows"
 	
 	tests := []struct {
 		name    string
@@ -174,3 +177,46 @@ func TestMavenProvider_GetDefaultInstallPath(t *testing.T) {
 		t.Errorf("GetDefaultInstallPath() should contain version %v, got %v", version, path)
 	}
 }
+
+func TestMavenProvider_GetChecksum(t *testing.T) {
+	sha512 := strings.Repeat("ab", 64)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/3.9.14/binaries/apache-maven-3.9.14-bin.zip.sha512" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(sha512 + "  apache-maven-3.9.14-bin.zip\n"))
+	}))
+	defer server.Close()
+
+	provider := NewMavenProvider()
+	provider.goos = "windows"
+	provider.baseURL = server.URL
+
+	got, err := provider.GetChecksum(context.Background(), "3.9.14", "x64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if got != sha512 {
+		t.Errorf("GetChecksum() = %v, want %v", got, sha512)
+	}
+
+	if _, err := provider.GetChecksum(context.Background(), "9.9.9", "x64"); err == nil {
+		t.Error("GetChecksum() should fail for an unsupported version")
+	}
+}
+
+func TestMavenProvider_GetDownloadURL_Unix(t *testing.T) {
+	provider := NewMavenProvider()
+	provider.goos = "linux"
+
+	got, err := provider.GetDownloadURL("3.9.14", "amd64")
+	if err != nil {
+		t.Fatalf("GetDownloadURL() error = %v", err)
+	}
+	want := "https://archive.apache.org/dist/maven/maven-3/3.9.14/binaries/apache-maven-3.9.14-bin.tar.gz"
+	if got != want {
+		t.Errorf("GetDownloadURL() = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,12 @@
 package mingw
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/javaquery/unosdk/internal/providers/remote"
+)
 
 // MinGW-w64 download URLs and version information
 // Shared between C and C++ providers
@@ -78,6 +84,15 @@ func DownloadURLs() map[string]map[string]string {
 	}
 }
 
+// CheckOS returns an error on operating systems other than Windows, where
+// GCC comes from the system package manager instead
+func CheckOS(goos string) error {
+	if goos != "windows" {
+		return fmt.Errorf("MinGW-w64 is only available on Windows, install GCC with your system package manager on %s", goos)
+	}
+	return nil
+}
+
 // GetDownloadURL returns the download URL for a specific version and architecture
 func GetDownloadURL(version string, arch string) (string, error) {
 	// Map architecture to MinGW-w64 naming convention
@@ -103,3 +118,14 @@ func GetDownloadURL(version string, arch string) (string, error) {
 	}
 	return url, nil
 }
+
+// GetChecksum returns the SHA-256 published alongside the winlibs release asset
+func GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// winlibs attaches a ".sha256" file to the release for every archive
+	return remote.FetchChecksum(ctx, downloadURL+".sha256", path.Base(downloadURL))
+}
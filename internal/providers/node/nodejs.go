@@ -4,17 +4,37 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
+	"github.com/javaquery/unosdk/internal/registry"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
-// NodeJSProvider implements the Provider interface for Node.js
-type NodeJSProvider struct{}
+// NodeJSProvider implements the Provider interface for Node.js, reading
+// releases from the nodejs.org distribution index
+type NodeJSProvider struct {
+	baseURL string
+	goos    string
+	cache   *registry.Cache
+}
 
 // NewNodeJSProvider creates a new Node.js provider
 func NewNodeJSProvider() *NodeJSProvider {
-	return &NodeJSProvider{}
+	return &NodeJSProvider{
+		baseURL: "https://nodejs.org/dist",
+		goos:    runtime.GOOS,
+		cache:   registry.NewDefaultCache(),
+	}
+}
+
+// nodeRelease is a release listed in the distribution index
+type nodeRelease struct {
+	Version string `json:"version"`
+	LTS     string `json:"lts,omitempty"`
 }
 
 func (p *NodeJSProvider) Name() string {
@@ -29,37 +49,149 @@ func (p *NodeJSProvider) Type() models.SDKType {
 	return models.NodeSDK
 }
 
+// GetVersions returns every release that ships a build for the platform, newest first
 func (p *NodeJSProvider) GetVersions(ctx context.Context) ([]string, error) {
-	return []string{
-		"24.14.0",
-		"22.22.1",
-		"20.20.1",
-	}, nil
+	releases, err := p.index(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		versions = append(versions, release.Version)
+	}
+	return versions, nil
 }
 
 func (p *NodeJSProvider) GetLatestVersion(ctx context.Context) (string, error) {
-	return "24.14.0", nil
+	return p.ResolveVersion(ctx, "latest")
+}
+
+// ResolveVersion resolves "latest", "lts", "lts/<codename>" and partial
+// versions like "22" or "22.11" to the newest matching release
+func (p *NodeJSProvider) ResolveVersion(ctx context.Context, version string) (string, error) {
+	alias := strings.ToLower(strings.TrimPrefix(version, "v"))
+
+	var match func(release nodeRelease) bool
+	switch {
+	case alias == "latest" || alias == "current" || alias == "node":
+		match = func(release nodeRelease) bool { return true }
+	case alias == "lts" || alias == "lts/*":
+		match = func(release nodeRelease) bool { return release.LTS != "" }
+	case strings.HasPrefix(alias, "lts/"):
+		codename := strings.TrimPrefix(alias, "lts/")
+		match = func(release nodeRelease) bool { return strings.ToLower(release.LTS) == codename }
+	case strings.Count(alias, ".") == 2:
+		match = func(release nodeRelease) bool { return release.Version == alias }
+	default:
+		match = func(release nodeRelease) bool { return strings.HasPrefix(release.Version, alias+".") }
+	}
+
+	releases, err := p.index(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The index is ordered newest first
+	for _, release := range releases {
+		if match(release) {
+			return release.Version, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Node.js release matches %s", version)
 }
 
 func (p *NodeJSProvider) GetDownloadURL(version string, arch string) (string, error) {
-	// Map common architecture names to Node.js naming convention
-	switch arch {
-	case "", "amd64", "x86_64":
-		arch = "x64"
-	case "x86", "i386":
-		arch = "x86"
-	case "arm64", "aarch64":
-		arch = "arm64"
+	version = strings.TrimPrefix(version, "v")
+
+	var fileName string
+	switch p.goos {
+	case "windows":
+		fileName = fmt.Sprintf("node-v%s-win-%s.zip", version, nodeArch(arch))
+	case "linux":
+		fileName = fmt.Sprintf("node-v%s-linux-%s.tar.xz", version, nodeArch(arch))
+	case "darwin":
+		fileName = fmt.Sprintf("node-v%s-darwin-%s.tar.gz", version, nodeArch(arch))
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", p.goos)
 	}
 
-	baseURL := "https://nodejs.org/dist"
-	fileName := fmt.Sprintf("node-v%s-win-%s.zip", version, arch)
-	
-	return fmt.Sprintf("%s/v%s/%s", baseURL, version, fileName), nil
+	return fmt.Sprintf("%s/v%s/%s", p.baseURL, version, fileName), nil
 }
 
-func (p *NodeJSProvider) GetChecksum(version string, arch string) (string, error) {
-	return "", nil
+func (p *NodeJSProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	downloadURL, err := p.GetDownloadURL(version, arch)
+	if err != nil {
+		return "", err
+	}
+
+	// Each release directory has a SHASUMS256.txt covering all of its files
+	checksumURL := fmt.Sprintf("%s/v%s/SHASUMS256.txt", p.baseURL, strings.TrimPrefix(version, "v"))
+	return remote.FetchChecksum(ctx, checksumURL, path.Base(downloadURL))
+}
+
+// index returns the releases that ship a build for the platform, newest first
+func (p *NodeJSProvider) index(ctx context.Context) ([]nodeRelease, error) {
+	key := "nodejs_index_" + p.goos
+	var releases []nodeRelease
+	if p.cache.Load(key, &releases) {
+		return releases, nil
+	}
+
+	// "lts" is either false or the codename of the LTS line
+	var index []struct {
+		Version string      `json:"version"`
+		LTS     interface{} `json:"lts"`
+		Files   []string    `json:"files"`
+	}
+	if err := remote.FetchJSON(ctx, p.baseURL+"/index.json", &index); err != nil {
+		return nil, fmt.Errorf("failed to fetch Node.js index: %w", err)
+	}
+
+	for _, entry := range index {
+		release := nodeRelease{Version: strings.TrimPrefix(entry.Version, "v")}
+		if codename, ok := entry.LTS.(string); ok {
+			release.LTS = codename
+		}
+		for _, file := range entry.Files {
+			if p.hasBuild(file) {
+				releases = append(releases, release)
+				break
+			}
+		}
+	}
+
+	p.cache.Set(key, releases)
+	return releases, nil
+}
+
+// hasBuild reports whether a file of the index is the archive downloaded for the platform
+func (p *NodeJSProvider) hasBuild(file string) bool {
+	switch p.goos {
+	case "windows":
+		return strings.HasPrefix(file, "win-") && strings.HasSuffix(file, "-zip")
+	case "linux":
+		return strings.HasPrefix(file, "linux-")
+	case "darwin":
+		return strings.HasPrefix(file, "osx-") && strings.HasSuffix(file, "-tar")
+	default:
+		return false
+	}
+}
+
+// nodeArch maps common architecture names to Node.js naming convention
+func nodeArch(arch string) string {
+	switch arch {
+	case "", "amd64", "x86_64":
+		return "x64"
+	case "386", "i386":
+		return "x86"
+	case "aarch64":
+		return "arm64"
+	default:
+		return arch
+	}
 }
 
 func (p *NodeJSProvider) GetDefaultInstallPath(version string) string {
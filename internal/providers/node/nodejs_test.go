@@ -0,0 +1,194 @@
+package node
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/registry"
+)
+
+// testIndex is a trimmed down copy of https://nodejs.org/dist/index.json
+const testIndex = `[
+	{"version":"v25.2.0","files":["linux-x64","osx-arm64-tar","win-x64-zip","win-arm64-zip"],"lts":false},
+	{"version":"v24.11.1","files":["linux-x64","win-x64-zip","win-arm64-zip"],"lts":"Krypton"},
+	{"version":"v24.11.0","files":["linux-x64","win-x64-zip"],"lts":"Krypton"},
+	{"version":"v23.11.1","files":["linux-x64","win-x64-zip"],"lts":false},
+	{"version":"v22.21.1","files":["linux-x64","win-x64-zip","win-x86-zip"],"lts":"Jod"},
+	{"version":"v20.19.5","files":["linux-x64","win-x64-zip","win-x86-zip"],"lts":"Iron"},
+	{"version":"v0.12.18","files":["linux-x64","win-x64-exe"],"lts":false}
+]`
+
+func newTestProvider(t *testing.T) (*NodeJSProvider, *int) {
+	t.Helper()
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/index.json":
+			w.Write([]byte(testIndex))
+		case "/v22.21.1/SHASUMS256.txt":
+			w.Write([]byte(strings.Repeat("ab", 32) + "  node-v22.21.1-win-x64.zip\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewNodeJSProvider()
+	provider.baseURL = server.URL
+	provider.goos = "windows"
+	provider.cache = registry.NewCache(t.TempDir(), time.Hour)
+	return provider, &requests
+}
+
+func TestNodeJSProvider_Name(t *testing.T) {
+	provider := NewNodeJSProvider()
+	if got := provider.Name(); got != "nodejs" {
+		t.Errorf("Name() = %v, want %v", got, "nodejs")
+	}
+}
+
+func TestNodeJSProvider_GetVersions(t *testing.T) {
+	provider, requests := newTestProvider(t)
+
+	versions, err := provider.GetVersions(context.Background())
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+
+	// v0.12.18 has no Windows zip build
+	want := []string{"25.2.0", "24.11.1", "24.11.0", "23.11.1", "22.21.1", "20.19.5"}
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("GetVersions() = %v, want %v", versions, want)
+	}
+
+	// The index is served from the cache afterwards
+	if _, err := provider.GetVersions(context.Background()); err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	if *requests != 1 {
+		t.Errorf("index fetched %d times, want 1", *requests)
+	}
+}
+
+func TestNodeJSProvider_GetVersions_Platforms(t *testing.T) {
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"linux", []string{"25.2.0", "24.11.1", "24.11.0", "23.11.1", "22.21.1", "20.19.5", "0.12.18"}},
+		{"darwin", []string{"25.2.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			provider, _ := newTestProvider(t)
+			provider.goos = tt.goos
+
+			versions, err := provider.GetVersions(context.Background())
+			if err != nil {
+				t.Fatalf("GetVersions() error = %v", err)
+			}
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("GetVersions() = %v, want %v", versions, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeJSProvider_GetLatestVersion(t *testing.T) {
+	provider, _ := newTestProvider(t)
+
+	version, err := provider.GetLatestVersion(context.Background())
+	if err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if version != "25.2.0" {
+		t.Errorf("GetLatestVersion() = %v, want %v", version, "25.2.0")
+	}
+}
+
+func TestNodeJSProvider_ResolveVersion(t *testing.T) {
+	provider, _ := newTestProvider(t)
+
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "latest", want: "25.2.0"},
+		{version: "lts", want: "24.11.1"},
+		{version: "lts/*", want: "24.11.1"},
+		{version: "lts/jod", want: "22.21.1"},
+		{version: "lts/Iron", want: "20.19.5"},
+		{version: "22", want: "22.21.1"},
+		{version: "24.11", want: "24.11.1"},
+		{version: "v24.11.0", want: "24.11.0"},
+		{version: "20.19.5", want: "20.19.5"},
+		{version: "lts/argon", wantErr: true},
+		{version: "2", wantErr: true},
+		{version: "0.12.18", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := provider.ResolveVersion(context.Background(), tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeJSProvider_GetDownloadURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		version string
+		arch    string
+		want    string
+	}{
+		{"amd64", "windows", "22.21.1", "amd64", "https://nodejs.org/dist/v22.21.1/node-v22.21.1-win-x64.zip"},
+		{"386", "windows", "22.21.1", "386", "https://nodejs.org/dist/v22.21.1/node-v22.21.1-win-x86.zip"},
+		{"arm64", "windows", "24.11.1", "arm64", "https://nodejs.org/dist/v24.11.1/node-v24.11.1-win-arm64.zip"},
+		{"leading v", "windows", "v24.11.1", "x64", "https://nodejs.org/dist/v24.11.1/node-v24.11.1-win-x64.zip"},
+		{"linux", "linux", "22.21.1", "amd64", "https://nodejs.org/dist/v22.21.1/node-v22.21.1-linux-x64.tar.xz"},
+		{"darwin", "darwin", "22.21.1", "arm64", "https://nodejs.org/dist/v22.21.1/node-v22.21.1-darwin-arm64.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewNodeJSProvider()
+			provider.goos = tt.goos
+
+			got, err := provider.GetDownloadURL(tt.version, tt.arch)
+			if err != nil {
+				t.Fatalf("GetDownloadURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeJSProvider_GetChecksum(t *testing.T) {
+	provider, _ := newTestProvider(t)
+
+	got, err := provider.GetChecksum(context.Background(), "22.21.1", "amd64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if want := strings.Repeat("ab", 32); got != want {
+		t.Errorf("GetChecksum() = %v, want %v", got, want)
+	}
+}
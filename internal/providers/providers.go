@@ -2,6 +2,8 @@ package providers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/javaquery/unosdk/pkg/models"
 )
@@ -26,8 +28,9 @@ type Provider interface {
 	// GetDownloadURL returns the download URL for a specific version
 	GetDownloadURL(version string, arch string) (string, error)
 
-	// GetChecksum returns the checksum for verification
-	GetChecksum(version string, arch string) (string, error)
+	// GetChecksum returns the vendor-published checksum (hex encoded SHA-256 or
+	// SHA-512) of the download for verification
+	GetChecksum(ctx context.Context, version string, arch string) (string, error)
 
 	// GetDefaultInstallPath returns the default installation path
 	GetDefaultInstallPath(version string) string
@@ -36,9 +39,62 @@ type Provider interface {
 	Validate(version string) error
 }
 
-// Registry holds all registered providers
+// VersionResolver is implemented by providers that accept version aliases
+// (e.g. "lts" or a bare major version) in addition to exact versions
+type VersionResolver interface {
+	// ResolveVersion resolves a version or alias to an exact version
+	ResolveVersion(ctx context.Context, version string) (string, error)
+}
+
+// DownloadURLResolver is implemented by providers that look download URLs up
+// remotely, so that the lookup can be cancelled with the caller's context
+type DownloadURLResolver interface {
+	// ResolveDownloadURL returns the download URL for a specific version
+	ResolveDownloadURL(ctx context.Context, version string, arch string) (string, error)
+}
+
+// EnvProvider is implemented by providers that declare their own environment
+// rules instead of using the defaults of their SDK type (models.DefaultEnvSpec)
+type EnvProvider interface {
+	// EnvSpec returns the environment variables and PATH entries of the SDK
+	EnvSpec() models.EnvSpec
+}
+
+// ResolveVersion resolves "latest" and any provider-specific aliases to an
+// exact version. Other versions are returned unchanged.
+func ResolveVersion(ctx context.Context, provider Provider, version string) (string, error) {
+	if resolver, ok := provider.(VersionResolver); ok {
+		resolved, err := resolver.ResolveVersion(ctx, version)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
+		}
+		return resolved, nil
+	}
+
+	if version == "latest" {
+		latest, err := provider.GetLatestVersion(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest version: %w", err)
+		}
+		return latest, nil
+	}
+
+	return version, nil
+}
+
+// DownloadURL returns the download URL for a version, using the caller's
+// context for providers that look it up remotely
+func DownloadURL(ctx context.Context, provider Provider, version, arch string) (string, error) {
+	if resolver, ok := provider.(DownloadURLResolver); ok {
+		return resolver.ResolveDownloadURL(ctx, version, arch)
+	}
+	return provider.GetDownloadURL(version, arch)
+}
+
+// Registry holds all registered providers in registration order
 type Registry struct {
 	providers map[string]Provider
+	order     []string
 }
 
 // NewRegistry creates a new provider registry
@@ -48,9 +104,13 @@ func NewRegistry() *Registry {
 	}
 }
 
-// Register adds a provider to the registry
+// Register adds a provider to the registry. Registering a provider with the
+// same type and name again replaces it but keeps its original position.
 func (r *Registry) Register(provider Provider) {
 	key := string(provider.Type()) + ":" + provider.Name()
+	if _, exists := r.providers[key]; !exists {
+		r.order = append(r.order, key)
+	}
 	r.providers[key] = provider
 }
 
@@ -61,10 +121,29 @@ func (r *Registry) Get(sdkType models.SDKType, providerName string) (Provider, b
 	return provider, ok
 }
 
-// List returns all providers for a given SDK type
+// Lookup retrieves a provider by SDK type and name, returning an error that
+// lists the valid choices if the type or provider is unknown
+func (r *Registry) Lookup(sdkType models.SDKType, providerName string) (Provider, error) {
+	if !r.HasType(sdkType) {
+		return nil, fmt.Errorf("invalid SDK type: %s (valid types: %s)", sdkType, strings.Join(r.typeNames(), ", "))
+	}
+
+	provider, ok := r.Get(sdkType, providerName)
+	if !ok {
+		var names []string
+		for _, p := range r.List(sdkType) {
+			names = append(names, p.Name())
+		}
+		return nil, fmt.Errorf("unknown %s provider: %s (valid providers: %s)", sdkType, providerName, strings.Join(names, ", "))
+	}
+
+	return provider, nil
+}
+
+// List returns all providers for a given SDK type in registration order
 func (r *Registry) List(sdkType models.SDKType) []Provider {
 	var result []Provider
-	for _, provider := range r.providers {
+	for _, provider := range r.ListAll() {
 		if provider.Type() == sdkType {
 			result = append(result, provider)
 		}
@@ -72,11 +151,38 @@ func (r *Registry) List(sdkType models.SDKType) []Provider {
 	return result
 }
 
-// ListAll returns all registered providers
+// ListAll returns all registered providers in registration order
 func (r *Registry) ListAll() []Provider {
-	var result []Provider
-	for _, provider := range r.providers {
-		result = append(result, provider)
+	result := make([]Provider, 0, len(r.order))
+	for _, key := range r.order {
+		result = append(result, r.providers[key])
 	}
 	return result
 }
+
+// Types returns the SDK types with at least one provider in registration order
+func (r *Registry) Types() []models.SDKType {
+	var types []models.SDKType
+	seen := make(map[models.SDKType]bool)
+	for _, provider := range r.ListAll() {
+		if !seen[provider.Type()] {
+			seen[provider.Type()] = true
+			types = append(types, provider.Type())
+		}
+	}
+	return types
+}
+
+// HasType reports whether any provider is registered for the SDK type
+func (r *Registry) HasType(sdkType models.SDKType) bool {
+	return len(r.List(sdkType)) > 0
+}
+
+// typeNames returns the registered SDK types as strings
+func (r *Registry) typeNames() []string {
+	var names []string
+	for _, sdkType := range r.Types() {
+		names = append(names, string(sdkType))
+	}
+	return names
+}
@@ -2,6 +2,10 @@ package providers
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/javaquery/unosdk/pkg/models"
@@ -40,7 +44,7 @@ func (m *mockProvider) GetDownloadURL(version string, arch string) (string, erro
 	return "https://example.com/" + version, nil
 }
 
-func (m *mockProvider) GetChecksum(version string, arch string) (string, error) {
+func (m *mockProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
 	return "checksum123", nil
 }
 
@@ -234,3 +238,212 @@ func TestRegistry_MultipleProvidersPerType(t *testing.T) {
 		t.Errorf("List(JavaSDK) returned %d providers, want 3", len(javaProviders))
 	}
 }
+
+// aliasProvider is a mockProvider that resolves version aliases
+type aliasProvider struct {
+	mockProvider
+	aliases map[string]string
+}
+
+func (a *aliasProvider) ResolveVersion(ctx context.Context, version string) (string, error) {
+	if resolved, ok := a.aliases[version]; ok {
+		return resolved, nil
+	}
+	return version, nil
+}
+
+func TestResolveVersion(t *testing.T) {
+	plain := &mockProvider{latestVer: "2.0.0"}
+	aliased := &aliasProvider{
+		mockProvider: mockProvider{latestVer: "2.0.0"},
+		aliases:      map[string]string{"lts": "1.8.0", "latest": "2.1.0"},
+	}
+
+	tests := []struct {
+		name     string
+		provider Provider
+		version  string
+		want     string
+	}{
+		{"latest without resolver", plain, "latest", "2.0.0"},
+		{"exact version without resolver", plain, "1.0.0", "1.0.0"},
+		{"alias", aliased, "lts", "1.8.0"},
+		{"latest handled by resolver", aliased, "latest", "2.1.0"},
+		{"exact version with resolver", aliased, "1.0.0", "1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveVersion(context.Background(), tt.provider, tt.version)
+			if err != nil {
+				t.Fatalf("ResolveVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// remoteProvider is a mockProvider that looks download URLs up with the
+// caller's context
+type remoteProvider struct {
+	mockProvider
+}
+
+func (r *remoteProvider) ResolveDownloadURL(ctx context.Context, version string, arch string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return "https://remote.example.com/" + version, nil
+}
+
+func TestDownloadURL(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		provider Provider
+		want     string
+		wantErr  bool
+	}{
+		{"without resolver", context.Background(), &mockProvider{}, "https://example.com/1.0.0", false},
+		{"with resolver", context.Background(), &remoteProvider{}, "https://remote.example.com/1.0.0", false},
+		{"cancelled resolver", cancelled, &remoteProvider{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DownloadURL(tt.ctx, tt.provider, "1.0.0", "amd64")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Order(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&mockProvider{name: "b", sdkType: models.NodeSDK})
+	registry.Register(&mockProvider{name: "a", sdkType: models.JavaSDK})
+	registry.Register(&mockProvider{name: "c", sdkType: models.NodeSDK})
+	// Re-registering keeps the original position
+	registry.Register(&mockProvider{name: "b", sdkType: models.NodeSDK, displayName: "B"})
+
+	var got []string
+	for _, provider := range registry.ListAll() {
+		got = append(got, provider.Name())
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAll() order = %v, want %v", got, want)
+	}
+
+	if got, want := registry.Types(), []models.SDKType{models.NodeSDK, models.JavaSDK}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Types() = %v, want %v", got, want)
+	}
+
+	if provider, _ := registry.Get(models.NodeSDK, "b"); provider.DisplayName() != "B" {
+		t.Error("Register() should replace an existing provider")
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(&mockProvider{name: "openjdk", sdkType: models.JavaSDK})
+
+	if _, err := registry.Lookup(models.JavaSDK, "openjdk"); err != nil {
+		t.Errorf("Lookup() error = %v", err)
+	}
+
+	_, err := registry.Lookup(models.NodeSDK, "nodejs")
+	if err == nil || !strings.Contains(err.Error(), "valid types: java") {
+		t.Errorf("Lookup() of unknown type error = %v, want list of valid types", err)
+	}
+
+	_, err = registry.Lookup(models.JavaSDK, "zulu")
+	if err == nil || !strings.Contains(err.Error(), "valid providers: openjdk") {
+		t.Errorf("Lookup() of unknown provider error = %v, want list of valid providers", err)
+	}
+}
+
+// setTestHome points the user's home directory at a temporary directory
+func setTestHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	return home
+}
+
+func TestDefault(t *testing.T) {
+	setTestHome(t)
+	catalog := Default()
+
+	wantTypes := []models.SDKType{
+		models.JavaSDK, models.NodeSDK, models.PythonSDK, models.FlutterSDK,
+		models.MavenSDK, models.GradleSDK, models.GoSDK, models.CppSDK, models.CSDK,
+	}
+	if got := catalog.Types(); !reflect.DeepEqual(got, wantTypes) {
+		t.Errorf("Default().Types() = %v, want %v", got, wantTypes)
+	}
+
+	var java []string
+	for _, provider := range catalog.List(models.JavaSDK) {
+		java = append(java, provider.Name())
+	}
+	if want := []string{"amazoncorretto", "openjdk", "graalvm"}; !reflect.DeepEqual(java, want) {
+		t.Errorf("Default().List(java) = %v, want %v", java, want)
+	}
+
+	// Every call yields the same order
+	first, second := Default().ListAll(), Default().ListAll()
+	for i := range first {
+		if first[i].Type() != second[i].Type() || first[i].Name() != second[i].Name() {
+			t.Fatalf("Default() order is not deterministic at %d", i)
+		}
+	}
+}
+
+func TestDefault_UserProviders(t *testing.T) {
+	home := setTestHome(t)
+	configDir := filepath.Join(home, ".unosdk")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	userFile := `
+providers:
+  - type: protoc
+    name: protobuf
+    url: https://example.com/protoc-{version}.zip
+    versions: ["29.3"]
+  - type: java
+    name: openjdk
+    display_name: Internal OpenJDK mirror
+    url: https://mirror.example.com/jdk-{version}.zip
+    versions: ["21.0.10"]
+`
+	if err := os.WriteFile(filepath.Join(configDir, "sdks.yaml"), []byte(userFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := Default()
+
+	if !catalog.HasType("protoc") {
+		t.Error("Default() should include user declared SDK types")
+	}
+
+	// User providers replace built-in providers with the same type and name
+	provider, ok := catalog.Get(models.JavaSDK, "openjdk")
+	if !ok || provider.DisplayName() != "Internal OpenJDK mirror" {
+		t.Errorf("Default() should let the user file override built-in providers")
+	}
+	if _, ok := provider.(EnvProvider); !ok {
+		t.Error("declarative providers should implement EnvProvider")
+	}
+}
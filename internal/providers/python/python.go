@@ -2,19 +2,32 @@ package python
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/javaquery/unosdk/internal/providers/remote"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
 // PythonProvider implements the Provider interface for Python
-type PythonProvider struct{}
+type PythonProvider struct {
+	nugetURL string
+	goos     string
+}
 
-// NewPythonProvider creates a new Python provider
+// NewPythonProvider creates a new Python provider installing the "python"
+// NuGet package, a plain zip containing the full interpreter, standard library
+// and pip bootstrap
 func NewPythonProvider() *PythonProvider {
-	return &PythonProvider{}
+	return &PythonProvider{
+		nugetURL: "https://api.nuget.org",
+		goos:     runtime.GOOS,
+	}
 }
 
 func (p *PythonProvider) Name() string {
@@ -43,18 +56,54 @@ func (p *PythonProvider) GetLatestVersion(ctx context.Context) (string, error) {
 }
 
 func (p *PythonProvider) GetDownloadURL(version string, arch string) (string, error) {
-	if arch == "" {
-		arch = "amd64"
+	// python.org only publishes relocatable builds for Windows
+	if p.goos != "windows" {
+		return "", fmt.Errorf("Python is only available on Windows, install it with your system package manager or pyenv on %s", p.goos)
 	}
 
-	baseURL := "https://www.python.org/ftp/python"
-	fileName := fmt.Sprintf("python-%s-%s.exe", version, arch)
-	
-	return fmt.Sprintf("%s/%s/%s", baseURL, version, fileName), nil
+	id := nugetPackageID(arch)
+	return fmt.Sprintf("%s/v3-flatcontainer/%s/%s/%s.%s.nupkg", p.nugetURL, id, version, id, version), nil
 }
 
-func (p *PythonProvider) GetChecksum(version string, arch string) (string, error) {
-	return "", nil
+func (p *PythonProvider) GetChecksum(ctx context.Context, version string, arch string) (string, error) {
+	// The registration leaf links to the catalog entry holding the package hash
+	var registration struct {
+		CatalogEntry string `json:"catalogEntry"`
+	}
+	registrationURL := fmt.Sprintf("%s/v3/registration5-semver1/%s/%s.json", p.nugetURL, nugetPackageID(arch), version)
+	if err := remote.FetchJSON(ctx, registrationURL, &registration); err != nil {
+		return "", fmt.Errorf("failed to fetch NuGet registration: %w", err)
+	}
+
+	var catalog struct {
+		PackageHash          string `json:"packageHash"`
+		PackageHashAlgorithm string `json:"packageHashAlgorithm"`
+	}
+	if err := remote.FetchJSON(ctx, registration.CatalogEntry, &catalog); err != nil {
+		return "", fmt.Errorf("failed to fetch NuGet catalog entry: %w", err)
+	}
+	if !strings.EqualFold(catalog.PackageHashAlgorithm, "SHA512") {
+		return "", fmt.Errorf("unsupported NuGet package hash algorithm: %s", catalog.PackageHashAlgorithm)
+	}
+
+	// NuGet publishes the hash base64 encoded
+	digest, err := base64.StdEncoding.DecodeString(catalog.PackageHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid NuGet package hash: %w", err)
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+// nugetPackageID returns the NuGet package publishing Python for an architecture
+func nugetPackageID(arch string) string {
+	switch arch {
+	case "386", "x86", "win32":
+		return "pythonx86"
+	case "arm64", "aarch64":
+		return "pythonarm64"
+	default:
+		return "python"
+	}
 }
 
 func (p *PythonProvider) GetDefaultInstallPath(version string) string {
@@ -2,6 +2,12 @@ package python
 
 import (
 	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -60,60 +66,6 @@ func TestPythonProvider_GetLatestVersion(t *testing.T) {
 	}
 }
 
-func TestPythonProvider_GetDownloadURL(t *testing.T) {
-	provider := NewPythonProvider()
-	
-	tests := []struct {
-		name    string
-		version string
-		arch    string
-		want    string
-		wantErr bool
-	}{
-		{
-			name:    "Python 3.14.3 amd64 architecture",
-			version: "3.14.3",
-			arch:    "amd64",
-			want:    "https://www.python.org/ftp/python/3.14.3/python-3.14.3-amd64.exe",
-			wantErr: false,
-		},
-		{
-			name:    "Python 3.13.12 amd64 architecture",
-			version: "3.13.12",
-			arch:    "amd64",
-			want:    "https://www.python.org/ftp/python/3.13.12/python-3.13.12-amd64.exe",
-			wantErr: false,
-		},
-		{
-			name:    "Python 3.12.13 default architecture",
-			version: "3.12.13",
-			arch:    "",
-			want:    "https://www.python.org/ftp/python/3.12.13/python-3.12.13-amd64.exe",
-			wantErr: false,
-		},
-		{
-			name:    "Python 3.11.15 amd64 architecture",
-			version: "3.11.15",
-			arch:    "amd64",
-			want:    "https://www.python.org/ftp/python/3.11.15/python-3.11.15-amd64.exe",
-			wantErr: false,
-		},
-	}
-	
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := provider.GetDownloadURL(tt.version, tt.arch)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetDownloadURL() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if err == nil && got != tt.want {
-				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestPythonProvider_GetDefaultInstallPath(t *testing.T) {
 	provider := NewPythonProvider()
 	version := "3.14.3"
@@ -161,3 +113,73 @@ func TestPythonProvider_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPythonProvider_GetDownloadURL_NuGet(t *testing.T) {
+	provider := NewPythonProvider()
+	provider.goos = "windows"
+
+	tests := []struct {
+		name    string
+		version string
+		arch    string
+		want    string
+	}{
+		{"amd64", "3.14.3", "amd64", "https://api.nuget.org/v3-flatcontainer/python/3.14.3/python.3.14.3.nupkg"},
+		{"default architecture", "3.13.12", "", "https://api.nuget.org/v3-flatcontainer/python/3.13.12/python.3.13.12.nupkg"},
+		{"386", "3.14.3", "386", "https://api.nuget.org/v3-flatcontainer/pythonx86/3.14.3/pythonx86.3.14.3.nupkg"},
+		{"arm64", "3.14.3", "arm64", "https://api.nuget.org/v3-flatcontainer/pythonarm64/3.14.3/pythonarm64.3.14.3.nupkg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetDownloadURL(tt.version, tt.arch)
+			if err != nil {
+				t.Fatalf("GetDownloadURL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetDownloadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPythonProvider_GetChecksum(t *testing.T) {
+	digest := sha512.Sum512([]byte("python.3.14.3.nupkg"))
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/registration5-semver1/python/3.14.3.json":
+			fmt.Fprintf(w, `{"catalogEntry":"%s/v3/catalog0/data/python.3.14.3.json"}`, server.URL)
+		case "/v3/catalog0/data/python.3.14.3.json":
+			fmt.Fprintf(w, `{"packageHash":"%s","packageHashAlgorithm":"SHA512"}`, base64.StdEncoding.EncodeToString(digest[:]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewPythonProvider()
+	provider.nugetURL = server.URL
+
+	got, err := provider.GetChecksum(context.Background(), "3.14.3", "amd64")
+	if err != nil {
+		t.Fatalf("GetChecksum() error = %v", err)
+	}
+	if want := hex.EncodeToString(digest[:]); got != want {
+		t.Errorf("GetChecksum() = %v, want %v", got, want)
+	}
+
+	if _, err := provider.GetChecksum(context.Background(), "3.0.0", "amd64"); err == nil {
+		t.Error("GetChecksum() should fail for unknown packages")
+	}
+}
+
+func TestPythonProvider_GetDownloadURL_Unix(t *testing.T) {
+	provider := NewPythonProvider()
+	provider.goos = "darwin"
+
+	if _, err := provider.GetDownloadURL("3.14.3", "arm64"); err == nil {
+		t.Error("GetDownloadURL() should fail outside Windows")
+	}
+}
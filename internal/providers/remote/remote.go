@@ -0,0 +1,123 @@
+package remote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client is the HTTP client used for provider metadata requests
+// (version indexes, checksum files, release APIs)
+var Client = &http.Client{Timeout: 30 * time.Second}
+
+// maxBodySize caps metadata responses so a misbehaving server can't exhaust memory
+const maxBodySize = 32 << 20
+
+// Fetch downloads the body of the given URL
+func Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "unosdk")
+
+	resp, err := Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	return data, nil
+}
+
+// FetchText downloads the given URL and returns its body as a string
+func FetchText(ctx context.Context, url string) (string, error) {
+	data, err := Fetch(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FetchJSON downloads the given URL and decodes the JSON body into v
+func FetchJSON(ctx context.Context, url string, v interface{}) error {
+	data, err := Fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+
+	return nil
+}
+
+// FetchChecksum downloads a checksum file and returns the digest for fileName.
+// Both single-digest sidecars (e.g. "file.zip.sha256") and multi-line
+// "<digest>  <file>" listings (e.g. SHASUMS256.txt) are supported.
+func FetchChecksum(ctx context.Context, url, fileName string) (string, error) {
+	content, err := FetchText(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	return FindChecksum(content, fileName)
+}
+
+// FindChecksum extracts the digest for fileName from the content of a checksum file
+func FindChecksum(content, fileName string) (string, error) {
+	var lines [][]string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			lines = append(lines, fields)
+		}
+	}
+
+	// A sidecar file holds a single digest, optionally followed by the file name
+	if len(lines) == 1 && isHex(lines[0][0]) {
+		return strings.ToLower(lines[0][0]), nil
+	}
+
+	for _, fields := range lines {
+		if len(fields) < 2 || !isHex(fields[0]) {
+			continue
+		}
+		// sha256sum marks binary mode with a leading '*'
+		name := strings.TrimPrefix(fields[len(fields)-1], "*")
+		if name == fileName {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s not found", fileName)
+}
+
+// isHex reports whether s is a non-empty hexadecimal string
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindChecksum(t *testing.T) {
+	sha256 := "8d51d1ad5f0e1e1cd5e1e8cd1f0b4f8a1e4b3e1f6a9d4e0c3b2a1f0e9d8c7b6a"
+
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "sidecar with digest only",
+			content:  sha256 + "\n",
+			fileName: "gradle-8.12-bin.zip",
+			want:     sha256,
+		},
+		{
+			name:     "sidecar with digest and file name",
+			content:  sha256 + "  apache-maven-3.9.9-bin.zip\n",
+			fileName: "apache-maven-3.9.9-bin.zip",
+			want:     sha256,
+		},
+		{
+			name: "SHASUMS listing",
+			content: "1111111111111111111111111111111111111111111111111111111111111111  node-v22.1.0-linux-x64.tar.xz\n" +
+				sha256 + "  node-v22.1.0-win-x64.zip\n",
+			fileName: "node-v22.1.0-win-x64.zip",
+			want:     sha256,
+		},
+		{
+			name:     "binary mode marker",
+			content:  "aaaa  other.zip\n" + sha256 + " *file.zip\n",
+			fileName: "file.zip",
+			want:     sha256,
+		},
+		{
+			name:     "uppercase digest is normalized",
+			content:  "ABCDEF0123\n",
+			fileName: "file.zip",
+			want:     "abcdef0123",
+		},
+		{
+			name:     "file missing from listing",
+			content:  "aaaa  one.zip\nbbbb  two.zip\n",
+			fileName: "three.zip",
+			wantErr:  true,
+		},
+		{
+			name:     "empty content",
+			content:  "",
+			fileName: "file.zip",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindChecksum(tt.content, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FindChecksum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/file.zip.sha256" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "abc123  file.zip")
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	got, err := FetchChecksum(ctx, server.URL+"/file.zip.sha256", "file.zip")
+	if err != nil {
+		t.Fatalf("FetchChecksum() error = %v", err)
+	}
+	if got != "abc123" {
+		t.Errorf("FetchChecksum() = %v, want %v", got, "abc123")
+	}
+
+	if _, err := FetchChecksum(ctx, server.URL+"/missing.sha256", "file.zip"); err == nil {
+		t.Error("FetchChecksum() should fail on HTTP 404")
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/javaquery/unosdk/internal/config"
 	"github.com/javaquery/unosdk/pkg/models"
 )
 
+// DefaultCacheTTL is how long metadata fetched from vendor APIs is reused
+const DefaultCacheTTL = 6 * time.Hour
+
 // Cache manages cached provider information
 type Cache struct {
 	cachePath string
@@ -29,24 +33,46 @@ func NewCache(cachePath string, ttl time.Duration) *Cache {
 	}
 }
 
+// NewDefaultCache creates a metadata cache below the unosdk cache directory
+// using DefaultCacheTTL
+func NewDefaultCache() *Cache {
+	cacheDir := filepath.Join(os.TempDir(), "unosdk-cache")
+	if cfg, err := config.New(); err == nil {
+		cacheDir = cfg.CacheDir
+	}
+	return NewCache(filepath.Join(cacheDir, "metadata"), DefaultCacheTTL)
+}
+
 // Get retrieves a cached entry
 func (c *Cache) Get(key string) (interface{}, bool) {
+	var data interface{}
+	if !c.Load(key, &data) {
+		return nil, false
+	}
+	return data, true
+}
+
+// Load decodes a cached entry into v, returning false if it is missing or expired
+func (c *Cache) Load(key string, v interface{}) bool {
 	filePath := filepath.Join(c.cachePath, key+".json")
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, false
+		return false
 	}
 
-	var entry CacheEntry
+	var entry struct {
+		Data      json.RawMessage `json:"data"`
+		Timestamp time.Time       `json:"timestamp"`
+	}
 	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false
+		return false
 	}
 
 	if time.Since(entry.Timestamp) > c.ttl {
-		return nil, false
+		return false
 	}
 
-	return entry.Data, true
+	return json.Unmarshal(entry.Data, v) == nil
 }
 
 // Set stores a cache entry
@@ -76,12 +102,11 @@ func (c *Cache) Clear() error {
 
 // GetProviderVersions retrieves cached provider versions
 func (c *Cache) GetProviderVersions(provider string) ([]string, bool) {
-	data, ok := c.Get("provider_" + provider)
-	if !ok {
+	var versions []string
+	if !c.Load("provider_"+provider, &versions) {
 		return nil, false
 	}
-	versions, ok := data.([]string)
-	return versions, ok
+	return versions, true
 }
 
 // SetProviderVersions caches provider versions
@@ -91,12 +116,11 @@ func (c *Cache) SetProviderVersions(provider string, versions []string) error {
 
 // GetProviderInfo retrieves cached provider info
 func (c *Cache) GetProviderInfo(provider string) (*models.ProviderInfo, bool) {
-	data, ok := c.Get("info_" + provider)
-	if !ok {
+	var info models.ProviderInfo
+	if !c.Load("info_"+provider, &info) {
 		return nil, false
 	}
-	info, ok := data.(*models.ProviderInfo)
-	return info, ok
+	return &info, true
 }
 
 // SetProviderInfo caches provider info
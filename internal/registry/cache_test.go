@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestCache_ProviderVersions(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+	want := []string{"25.0.2", "21.0.10"}
+
+	if err := cache.SetProviderVersions("openjdk", want); err != nil {
+		t.Fatalf("SetProviderVersions() error = %v", err)
+	}
+
+	got, ok := cache.GetProviderVersions("openjdk")
+	if !ok {
+		t.Fatal("GetProviderVersions() should find cached versions")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetProviderVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestCache_ProviderInfo(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+	want := &models.ProviderInfo{Name: "openjdk", DisplayName: "OpenJDK", Type: models.JavaSDK}
+
+	if err := cache.SetProviderInfo("openjdk", want); err != nil {
+		t.Fatalf("SetProviderInfo() error = %v", err)
+	}
+
+	got, ok := cache.GetProviderInfo("openjdk")
+	if !ok {
+		t.Fatal("GetProviderInfo() should find cached info")
+	}
+	if got.Name != want.Name || got.DisplayName != want.DisplayName || got.Type != want.Type {
+		t.Errorf("GetProviderInfo() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCache_Expired(t *testing.T) {
+	cache := NewCache(t.TempDir(), 0)
+	cache.Set("key", "value")
+
+	var value string
+	if cache.Load("key", &value) {
+		t.Error("Load() should not return expired entries")
+	}
+	if cache.Load("missing", &value) {
+		t.Error("Load() should not find missing entries")
+	}
+}
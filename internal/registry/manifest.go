@@ -0,0 +1,286 @@
+package registry
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/internal/providers/remote"
+)
+
+// ManifestFileName is the name of the manifest below a registry URL and in the cache directory
+const ManifestFileName = "manifest.json"
+
+// SignatureSuffix is appended to the manifest location to find its detached signature
+const SignatureSuffix = ".sig"
+
+// TrustedPublicKey is the base64 encoded ed25519 key that registry manifests
+// must be signed with. Release builds set it via
+// -ldflags "-X github.com/javaquery/unosdk/internal/registry.TrustedPublicKey=..."
+var TrustedPublicKey = ""
+
+// Manifest lists the versions, download URLs and checksums of providers,
+// published independently of unosdk releases
+type Manifest struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Providers   []ManifestProvider `json:"providers"`
+}
+
+// ManifestProvider holds the releases of a single provider
+type ManifestProvider struct {
+	Type     string            `json:"type"`
+	Name     string            `json:"name"`
+	Versions []ManifestVersion `json:"versions"`
+}
+
+// ManifestVersion is a release with its platform specific downloads
+type ManifestVersion struct {
+	Version   string             `json:"version"`
+	Downloads []ManifestDownload `json:"downloads"`
+}
+
+// ManifestDownload is the download of a release for one platform
+type ManifestDownload struct {
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	URL      string `json:"url"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// Provider returns the manifest entry of a provider
+func (m *Manifest) Provider(sdkType, name string) (*ManifestProvider, bool) {
+	for i := range m.Providers {
+		if m.Providers[i].Type == sdkType && m.Providers[i].Name == name {
+			return &m.Providers[i], true
+		}
+	}
+	return nil, false
+}
+
+// Download returns the download of a version for a platform
+func (p *ManifestProvider) Download(version, goos, arch string) (*ManifestDownload, bool) {
+	arch = NormalizeArch(arch)
+	for _, v := range p.Versions {
+		if v.Version != version {
+			continue
+		}
+		for i := range v.Downloads {
+			if v.Downloads[i].OS == goos && NormalizeArch(v.Downloads[i].Arch) == arch {
+				return &v.Downloads[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// NormalizeArch maps the architecture names used across vendors to Go's names
+func NormalizeArch(arch string) string {
+	switch strings.ToLower(arch) {
+	case "x64", "x86_64", "amd64":
+		return "amd64"
+	case "x86", "i386", "386", "x86-32", "x32":
+		return "386"
+	case "aarch64", "arm64":
+		return "arm64"
+	default:
+		return strings.ToLower(arch)
+	}
+}
+
+// ParseManifest decodes a manifest
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	for _, provider := range manifest.Providers {
+		if provider.Type == "" || provider.Name == "" {
+			return nil, fmt.Errorf("invalid manifest: provider without type or name")
+		}
+	}
+	return &manifest, nil
+}
+
+// VerifyManifest checks the detached ed25519 signature (base64 encoded) of
+// a manifest against a base64 encoded public key
+func VerifyManifest(data, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid registry public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid manifest signature encoding")
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), data, sig) {
+		return fmt.Errorf("manifest signature verification failed")
+	}
+	return nil
+}
+
+// SyncOptions controls how a manifest is verified when it is synced and
+// when the synced copy is loaded
+type SyncOptions struct {
+	// PublicKey overrides TrustedPublicKey
+	PublicKey string
+	// AllowUnsigned skips signature verification
+	AllowUnsigned bool
+}
+
+// SettingsOptions returns the verification options configured in the
+// registry section of the user settings
+func SettingsOptions(settings *config.Settings) SyncOptions {
+	return SyncOptions{
+		PublicKey:     settings.Registry.PublicKey,
+		AllowUnsigned: settings.Registry.AllowUnsigned,
+	}
+}
+
+// publicKey returns the key manifests are verified with
+func (o SyncOptions) publicKey() (string, error) {
+	if o.PublicKey != "" {
+		return o.PublicKey, nil
+	}
+	if TrustedPublicKey != "" {
+		return TrustedPublicKey, nil
+	}
+	return "", fmt.Errorf("no registry public key configured: this build embeds none, set registry.public_key in ~/.unosdk/config.yaml or pass --public-key")
+}
+
+// SyncManifest downloads the manifest from source (a registry base URL, a
+// manifest URL or a local file path), verifies its signature and stores it
+// in the cache directory together with the signature
+func SyncManifest(ctx context.Context, source, cacheDir string, opts SyncOptions) (*Manifest, error) {
+	location := manifestLocation(source)
+
+	data, err := readSource(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest: %w", err)
+	}
+
+	var signature []byte
+	if !opts.AllowUnsigned {
+		publicKey, err := opts.publicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err = readSource(ctx, location+SignatureSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download manifest signature: %w", err)
+		}
+		if err := VerifyManifest(data, signature, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := ParseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// The signature is stored first so that a manifest is never cached
+	// next to the signature of an older one
+	signaturePath := filepath.Join(cacheDir, ManifestFileName+SignatureSuffix)
+	if signature != nil {
+		err = os.WriteFile(signaturePath, signature, 0644)
+	} else if err = os.Remove(signaturePath); os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store manifest signature: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, ManifestFileName), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to store manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// LoadManifest reads the synced manifest from the cache directory and
+// verifies its stored signature again, so that a manifest changed after the
+// sync is not used. It returns nil without an error if no manifest has been
+// synced.
+func LoadManifest(cacheDir string, opts SyncOptions) (*Manifest, error) {
+	path := filepath.Join(cacheDir, ManifestFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	if !opts.AllowUnsigned {
+		publicKey, err := opts.publicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := os.ReadFile(path + SignatureSuffix)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("the synced manifest has no signature, run unosdk registry sync again")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest signature: %w", err)
+		}
+		if err := VerifyManifest(data, signature, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return ParseManifest(data)
+}
+
+// LoadDefaultManifest reads the synced manifest from the unosdk cache
+// directory, verified as configured in the user settings
+func LoadDefaultManifest() (*Manifest, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := cfg.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
+	return LoadManifest(cfg.CacheDir, SettingsOptions(settings))
+}
+
+// manifestLocation resolves a registry base URL to its manifest
+func manifestLocation(source string) string {
+	if strings.HasSuffix(source, ".json") {
+		return source
+	}
+	if isURL(source) {
+		return strings.TrimSuffix(source, "/") + "/" + ManifestFileName
+	}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return filepath.Join(source, ManifestFileName)
+	}
+	return source
+}
+
+// readSource reads a URL or a local file
+func readSource(ctx context.Context, location string) ([]byte, error) {
+	if isURL(location) {
+		return remote.Fetch(ctx, location)
+	}
+	return os.ReadFile(strings.TrimPrefix(location, "file://"))
+}
+
+// isURL reports whether source is an HTTP(S) URL
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
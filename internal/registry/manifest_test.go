@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testManifest = `{
+	"generated_at": "2026-10-01T00:00:00Z",
+	"providers": [
+		{"type": "java", "name": "openjdk", "versions": [
+			{"version": "21.0.11", "downloads": [
+				{"os": "windows", "arch": "x64", "url": "https://example.com/jdk-21.0.11-x64.zip", "checksum": "sha256:aa"},
+				{"os": "windows", "arch": "aarch64", "url": "https://example.com/jdk-21.0.11-aarch64.zip"}
+			]}
+		]}
+	]
+}`
+
+// signManifest returns a base64 public key and the base64 signature of data
+func signManifest(t *testing.T, data []byte) (string, []byte) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signature := ed25519.Sign(privateKey, data)
+	return base64.StdEncoding.EncodeToString(publicKey), []byte(base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestManifest_Download(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+
+	provider, ok := manifest.Provider("java", "openjdk")
+	if !ok {
+		t.Fatal("Provider() should find java:openjdk")
+	}
+	if _, ok := manifest.Provider("java", "graalvm"); ok {
+		t.Error("Provider() should not find unlisted providers")
+	}
+
+	tests := []struct {
+		version string
+		arch    string
+		want    string
+	}{
+		{"21.0.11", "amd64", "https://example.com/jdk-21.0.11-x64.zip"},
+		{"21.0.11", "x86_64", "https://example.com/jdk-21.0.11-x64.zip"},
+		{"21.0.11", "arm64", "https://example.com/jdk-21.0.11-aarch64.zip"},
+		{"21.0.11", "386", ""},
+		{"21.0.10", "amd64", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+"-"+tt.arch, func(t *testing.T) {
+			download, ok := provider.Download(tt.version, "windows", tt.arch)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Download() = %v, want none", download.URL)
+				}
+				return
+			}
+			if !ok || download.URL != tt.want {
+				t.Errorf("Download() = %v, %v, want %v", download, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyManifest(t *testing.T) {
+	data := []byte(testManifest)
+	publicKey, signature := signManifest(t, data)
+	otherKey, _ := signManifest(t, data)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature []byte
+		publicKey string
+		wantErr   bool
+	}{
+		{"valid signature", data, signature, publicKey, false},
+		{"tampered manifest", append([]byte(" "), data...), signature, publicKey, true},
+		{"wrong key", data, signature, otherKey, true},
+		{"invalid key", data, signature, "not-a-key", true},
+		{"invalid signature", data, []byte("c2ln"), publicKey, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyManifest(tt.data, tt.signature, tt.publicKey)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSyncManifest_HTTP(t *testing.T) {
+	data := []byte(testManifest)
+	publicKey, signature := signManifest(t, data)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/registry/manifest.json":
+			w.Write(data)
+		case "/registry/manifest.json.sig":
+			w.Write(signature)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	manifest, err := SyncManifest(context.Background(), server.URL+"/registry", cacheDir, SyncOptions{PublicKey: publicKey})
+	if err != nil {
+		t.Fatalf("SyncManifest() error = %v", err)
+	}
+	if len(manifest.Providers) != 1 {
+		t.Errorf("SyncManifest() returned %d providers, want 1", len(manifest.Providers))
+	}
+
+	stored, err := LoadManifest(cacheDir, SyncOptions{PublicKey: publicKey})
+	if err != nil || stored == nil {
+		t.Fatalf("LoadManifest() = %v, %v, want the synced manifest", stored, err)
+	}
+	if _, ok := stored.Provider("java", "openjdk"); !ok {
+		t.Error("stored manifest should list java:openjdk")
+	}
+}
+
+func TestSyncManifest_File(t *testing.T) {
+	data := []byte(testManifest)
+	publicKey, signature := signManifest(t, data)
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+	os.WriteFile(manifestPath, data, 0644)
+
+	// No signature file yet
+	if _, err := SyncManifest(context.Background(), dir, t.TempDir(), SyncOptions{PublicKey: publicKey}); err == nil {
+		t.Error("SyncManifest() should fail without a signature")
+	}
+
+	// Unsigned manifests are only accepted when explicitly allowed
+	if _, err := SyncManifest(context.Background(), manifestPath, t.TempDir(), SyncOptions{AllowUnsigned: true}); err != nil {
+		t.Errorf("SyncManifest(AllowUnsigned) error = %v", err)
+	}
+
+	os.WriteFile(manifestPath+SignatureSuffix, signature, 0644)
+	if _, err := SyncManifest(context.Background(), dir, t.TempDir(), SyncOptions{PublicKey: publicKey}); err != nil {
+		t.Errorf("SyncManifest() error = %v", err)
+	}
+
+	// Without any configured key the sync is refused
+	if _, err := SyncManifest(context.Background(), dir, t.TempDir(), SyncOptions{}); err == nil {
+		t.Error("SyncManifest() should fail without a public key")
+	}
+
+	// A failed sync does not leave a manifest behind
+	cacheDir := t.TempDir()
+	SyncManifest(context.Background(), dir, cacheDir, SyncOptions{PublicKey: "invalid"})
+	if manifest, _ := LoadManifest(cacheDir, SyncOptions{AllowUnsigned: true}); manifest != nil {
+		t.Error("a failed sync should not store the manifest")
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	data := []byte(testManifest)
+	publicKey, signature := signManifest(t, data)
+	otherKey, _ := signManifest(t, data)
+
+	tests := []struct {
+		name      string
+		manifest  []byte
+		signature []byte
+		opts      SyncOptions
+		wantErr   bool
+	}{
+		{"valid signature", data, signature, SyncOptions{PublicKey: publicKey}, false},
+		{"changed after the sync", append(data, ' '), signature, SyncOptions{PublicKey: publicKey}, true},
+		{"wrong key", data, signature, SyncOptions{PublicKey: otherKey}, true},
+		{"no signature", data, nil, SyncOptions{PublicKey: publicKey}, true},
+		{"no key", data, signature, SyncOptions{}, true},
+		{"unsigned allowed", data, nil, SyncOptions{AllowUnsigned: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			os.WriteFile(filepath.Join(cacheDir, ManifestFileName), tt.manifest, 0644)
+			if tt.signature != nil {
+				os.WriteFile(filepath.Join(cacheDir, ManifestFileName+SignatureSuffix), tt.signature, 0644)
+			}
+
+			manifest, err := LoadManifest(cacheDir, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && manifest == nil {
+				t.Error("LoadManifest() = nil, want the stored manifest")
+			}
+		})
+	}
+}
+
+func TestSyncManifest_ReplacesSignature(t *testing.T) {
+	data := []byte(testManifest)
+	publicKey, signature := signManifest(t, data)
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ManifestFileName), data, 0644)
+	os.WriteFile(filepath.Join(dir, ManifestFileName+SignatureSuffix), signature, 0644)
+
+	cacheDir := t.TempDir()
+	if _, err := SyncManifest(context.Background(), dir, cacheDir, SyncOptions{PublicKey: publicKey}); err != nil {
+		t.Fatalf("SyncManifest() error = %v", err)
+	}
+
+	// An unsigned sync drops the signature of the previous manifest
+	if _, err := SyncManifest(context.Background(), dir, cacheDir, SyncOptions{AllowUnsigned: true}); err != nil {
+		t.Fatalf("SyncManifest(AllowUnsigned) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, ManifestFileName+SignatureSuffix)); !os.IsNotExist(err) {
+		t.Errorf("unsigned sync kept the old signature: %v", err)
+	}
+	if _, err := LoadManifest(cacheDir, SyncOptions{PublicKey: publicKey}); err == nil {
+		t.Error("LoadManifest() of an unsigned sync should fail when a signature is required")
+	}
+}
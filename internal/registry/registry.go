@@ -14,6 +14,10 @@ import (
 type Registry struct {
 	registryPath string
 	sdks         map[string]*models.SDK
+	// defaults maps each SDK type to the key of its default installation
+	defaults map[models.SDKType]string
+	// schema is the schema version of the file as it was read from disk
+	schema int
 }
 
 // NewRegistry creates a new SDK registry
@@ -33,6 +37,7 @@ func NewRegistry() (*Registry, error) {
 	r := &Registry{
 		registryPath: registryPath,
 		sdks:         make(map[string]*models.SDK),
+		defaults:     make(map[models.SDKType]string),
 	}
 
 	// Load existing registry
@@ -41,6 +46,10 @@ func NewRegistry() (*Registry, error) {
 		if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("failed to load registry: %w", err)
 		}
+	} else if r.schema < SchemaVersion {
+		if err := r.migrate(); err != nil {
+			return nil, fmt.Errorf("failed to migrate registry: %w", err)
+		}
 	}
 
 	return r, nil
@@ -50,19 +59,46 @@ func NewRegistry() (*Registry, error) {
 func (r *Registry) Add(sdk *models.SDK) error {
 	sdk.InstalledAt = time.Now()
 	sdk.Installed = true
-	
-	key := r.makeKey(sdk)
-	r.sdks[key] = sdk
 
-	return r.save()
+	return r.update(func() error {
+		r.sdks[r.makeKey(sdk)] = sdk
+		return r.save()
+	})
 }
 
 // Remove removes an SDK from the registry
 func (r *Registry) Remove(sdkType models.SDKType, provider, version string) error {
 	key := fmt.Sprintf("%s:%s:%s", sdkType, provider, version)
-	delete(r.sdks, key)
 
-	return r.save()
+	return r.update(func() error {
+		delete(r.sdks, key)
+
+		if r.defaults[sdkType] == key {
+			delete(r.defaults, sdkType)
+		}
+
+		return r.save()
+	})
+}
+
+// SetDefault records an installed SDK as the default of its type
+func (r *Registry) SetDefault(sdk *models.SDK) error {
+	key := r.makeKey(sdk)
+
+	return r.update(func() error {
+		if _, ok := r.sdks[key]; !ok {
+			return fmt.Errorf("SDK not installed: %s", key)
+		}
+
+		r.defaults[sdk.Type] = key
+		return r.save()
+	})
+}
+
+// Default returns the recorded default SDK of a type
+func (r *Registry) Default(sdkType models.SDKType) (*models.SDK, bool) {
+	sdk, ok := r.sdks[r.defaults[sdkType]]
+	return sdk, ok
 }
 
 // Get retrieves an SDK from the registry
@@ -97,30 +133,133 @@ func (r *Registry) makeKey(sdk *models.SDK) string {
 	return fmt.Sprintf("%s:%s:%s", sdk.Type, sdk.Provider, sdk.Version)
 }
 
-// save persists the registry to disk
+// update runs a read-modify-write cycle while holding registry.lock. The
+// registry is re-read under the lock first, so changes made by other unosdk
+// processes since it was loaded are not lost.
+func (r *Registry) update(mutate func() error) error {
+	lock, err := os.OpenFile(r.lockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open registry lock: %w", err)
+	}
+	defer lock.Close()
+
+	if err := lockFile(lock); err != nil {
+		return fmt.Errorf("failed to lock registry: %w", err)
+	}
+	defer unlockFile(lock)
+
+	if err := r.reload(); err != nil {
+		return err
+	}
+	return mutate()
+}
+
+// reload replaces the in-memory state with the file on disk
+func (r *Registry) reload() error {
+	r.sdks = make(map[string]*models.SDK)
+	r.defaults = make(map[models.SDKType]string)
+
+	if err := r.load(); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+	return nil
+}
+
+// lockPath returns the path of the lock file guarding registry writes
+func (r *Registry) lockPath() string {
+	return filepath.Join(filepath.Dir(r.registryPath), "registry.lock")
+}
+
+// save persists the registry to disk in the current schema
 func (r *Registry) save() error {
-	data, err := json.MarshalIndent(r.sdks, "", "  ")
+	doc := document{
+		SchemaVersion: SchemaVersion,
+		SDKs:          r.sdks,
+		Defaults:      r.defaults,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal registry: %w", err)
 	}
 
-	if err := os.WriteFile(r.registryPath, data, 0644); err != nil {
+	if err := writeFileAtomic(r.registryPath, data); err != nil {
 		return fmt.Errorf("failed to write registry: %w", err)
 	}
 
 	return nil
 }
 
-// load loads the registry from disk
+// load loads the registry from disk, upgrading files of older schemas in memory
 func (r *Registry) load() error {
 	data, err := os.ReadFile(r.registryPath)
 	if err != nil {
 		return err
 	}
 
-	if err := json.Unmarshal(data, &r.sdks); err != nil {
-		return fmt.Errorf("failed to unmarshal registry: %w", err)
+	doc, schema, err := decode(data)
+	if err != nil {
+		return err
 	}
 
+	r.schema = schema
+	if doc.SDKs != nil {
+		r.sdks = doc.SDKs
+	}
+	if doc.Defaults != nil {
+		r.defaults = doc.Defaults
+	}
 	return nil
 }
+
+// migrate rewrites a registry file of an older schema in the current one.
+// The original file is kept next to it as registry.json.v<N>.bak.
+func (r *Registry) migrate() error {
+	return r.update(func() error {
+		// Another process may have migrated the file in the meantime
+		if r.schema >= SchemaVersion {
+			return nil
+		}
+
+		original, err := os.ReadFile(r.registryPath)
+		if err != nil {
+			return fmt.Errorf("failed to read registry: %w", err)
+		}
+		backup := fmt.Sprintf("%s.v%d.bak", r.registryPath, r.schema)
+		if err := writeFileAtomic(backup, original); err != nil {
+			return fmt.Errorf("failed to back up registry: %w", err)
+		}
+
+		if err := r.save(); err != nil {
+			return err
+		}
+		r.schema = SchemaVersion
+		return nil
+	})
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// over path, so readers see either the old or the new content, never a
+// truncated file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	return reg
+}
+
+func TestRegistry_Default(t *testing.T) {
+	reg := newTestRegistry(t)
+	java21 := &models.SDK{Type: models.JavaSDK, Provider: "openjdk", Version: "21", InstallPath: "jdk-21"}
+	node := &models.SDK{Type: models.NodeSDK, Provider: "nodejs", Version: "20.10.0", InstallPath: "node-20"}
+
+	if err := reg.SetDefault(java21); err == nil {
+		t.Error("SetDefault() should fail for an SDK that is not installed")
+	}
+
+	reg.Add(java21)
+	reg.Add(node)
+	if err := reg.SetDefault(java21); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+
+	// Defaults survive a reload from disk
+	reloaded, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if got, ok := reloaded.Default(models.JavaSDK); !ok || got.InstallPath != "jdk-21" {
+		t.Errorf("Default(java) = %v, %v, want jdk-21", got, ok)
+	}
+	if _, ok := reloaded.Default(models.NodeSDK); ok {
+		t.Error("Default(node) should not be set")
+	}
+
+	// Removing the default SDK clears the default
+	if err := reloaded.Remove(models.JavaSDK, "openjdk", "21"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, ok := reloaded.Default(models.JavaSDK); ok {
+		t.Error("Default(java) should be cleared after removing the SDK")
+	}
+}
+
+func TestRegistry_ConcurrentAdd(t *testing.T) {
+	newTestRegistry(t)
+
+	// Separate instances stand in for separate unosdk processes
+	const count = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		reg, err := NewRegistry()
+		if err != nil {
+			t.Fatalf("NewRegistry() error = %v", err)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sdk := &models.SDK{Type: models.NodeSDK, Provider: "nodejs", Version: fmt.Sprintf("20.%d.0", i)}
+			errs <- reg.Add(sdk)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	reloaded, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if got := len(reloaded.List()); got != count {
+		t.Errorf("len(List()) = %d, want %d", got, count)
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(filepath.Dir(reloaded.registryPath))
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestRegistry_StaleInstance(t *testing.T) {
+	first := newTestRegistry(t)
+	second, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	java := &models.SDK{Type: models.JavaSDK, Provider: "openjdk", Version: "21"}
+	if err := first.Add(java); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// second was loaded before the Add, it must re-read before writing
+	if err := second.SetDefault(java); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	node := &models.SDK{Type: models.NodeSDK, Provider: "nodejs", Version: "20.10.0"}
+	if err := second.Add(node); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	reloaded, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if _, ok := reloaded.Get(models.JavaSDK, "openjdk", "21"); !ok {
+		t.Error("the SDK added through the other instance was lost")
+	}
+	if _, ok := reloaded.Default(models.JavaSDK); !ok {
+		t.Error("Default(java) not recorded")
+	}
+}
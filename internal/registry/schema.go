@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// SchemaVersion is the version of the registry.json format written by this
+// build. Files of older versions are upgraded through migrations when loaded.
+const SchemaVersion = 2
+
+// document is the registry.json format
+type document struct {
+	SchemaVersion int `json:"schema_version"`
+	// SDKs maps "<type>:<provider>:<version>" keys to installations
+	SDKs map[string]*models.SDK `json:"sdks"`
+	// Defaults maps each SDK type to the key of its default installation
+	Defaults map[models.SDKType]string `json:"defaults"`
+}
+
+// migrations upgrade a registry file to the next schema version; the entry at
+// index i upgrades version i+1
+var migrations = []func(data []byte) ([]byte, error){
+	migrateV1,
+}
+
+// decode parses a registry file of any known schema version and returns it in
+// the current schema, together with the version the file had
+func decode(data []byte) (*document, int, error) {
+	schema, err := schemaOf(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if schema > SchemaVersion {
+		return nil, 0, fmt.Errorf("registry schema %d is newer than the supported schema %d, please upgrade unosdk", schema, SchemaVersion)
+	}
+
+	for version := schema; version < SchemaVersion; version++ {
+		if data, err = migrations[version-1](data); err != nil {
+			return nil, 0, fmt.Errorf("failed to migrate registry from schema %d: %w", version, err)
+		}
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal registry: %w", err)
+	}
+	return &doc, schema, nil
+}
+
+// schemaOf returns the schema version of a registry file. Files without a
+// schema_version are the unversioned map of schema 1.
+func schemaOf(data []byte) (int, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal registry: %w", err)
+	}
+
+	raw, ok := probe["schema_version"]
+	if !ok {
+		return 1, nil
+	}
+	var schema int
+	if err := json.Unmarshal(raw, &schema); err != nil || schema < 1 {
+		return 0, fmt.Errorf("invalid registry schema_version: %s", raw)
+	}
+	return schema, nil
+}
+
+// migrateV1 wraps the unversioned map of installations into a document.
+// Schema 1 recorded no defaults. Downloaded installations get their source;
+// arch and size are unknown for them.
+func migrateV1(data []byte) ([]byte, error) {
+	var sdks map[string]*models.SDK
+	if err := json.Unmarshal(data, &sdks); err != nil {
+		return nil, err
+	}
+	if sdks == nil {
+		sdks = make(map[string]*models.SDK)
+	}
+
+	for _, sdk := range sdks {
+		if sdk.Source == "" && sdk.DownloadURL != "" {
+			sdk.Source = models.SourceDownload
+		}
+	}
+
+	return json.Marshal(document{SchemaVersion: 2, SDKs: sdks, Defaults: make(map[models.SDKType]string)})
+}
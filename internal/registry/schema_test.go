@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestNewRegistry_MigratesSchema1(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := filepath.Join(home, ".unosdk")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := `{
+  "java:openjdk:21": {"type": "java", "provider": "openjdk", "version": "21", "install_path": "jdk-21", "download_url": "https://example.com/jdk.tar.gz", "checksum": "sha256:abc", "installed": true},
+  "node:nodejs:20.10.0": {"type": "node", "provider": "nodejs", "version": "20.10.0", "install_path": "node-20", "installed": true}
+}`
+	writeFile(t, filepath.Join(dir, "registry.json"), legacy)
+
+	reg, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	if got := len(reg.List()); got != 2 {
+		t.Errorf("len(List()) = %d, want 2", got)
+	}
+	java, ok := reg.Get(models.JavaSDK, "openjdk", "21")
+	if !ok || java.InstallPath != "jdk-21" {
+		t.Fatalf("Get(java) = %v, %v, want jdk-21", java, ok)
+	}
+	if java.Source != models.SourceDownload || java.Checksum != "sha256:abc" {
+		t.Errorf("java Source = %q, Checksum = %q, want download and the legacy checksum", java.Source, java.Checksum)
+	}
+	// Schema 1 recorded no defaults
+	if _, ok := reg.Default(models.JavaSDK); ok {
+		t.Error("Default(java) should be unset after migrating schema 1")
+	}
+
+	// The file is rewritten in the current schema, the original is kept
+	var doc document
+	data, _ := os.ReadFile(filepath.Join(dir, "registry.json"))
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("migrated registry is invalid: %v", err)
+	}
+	if doc.SchemaVersion != SchemaVersion || doc.Defaults == nil || len(doc.Defaults) != 0 {
+		t.Errorf("migrated document = %+v, want schema %d with an empty defaults map", doc, SchemaVersion)
+	}
+	if backup, err := os.ReadFile(filepath.Join(dir, "registry.json.v1.bak")); err != nil || string(backup) != legacy {
+		t.Errorf("backup = %q, %v, want the original file", backup, err)
+	}
+
+	// Loading again needs no migration
+	if _, err := NewRegistry(); err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+}
+
+func TestNewRegistry_NewerSchema(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	dir := filepath.Join(home, ".unosdk")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "registry.json"), `{"schema_version": 99, "sdks": {}}`)
+
+	_, err := NewRegistry()
+	if err == nil || !strings.Contains(err.Error(), "upgrade unosdk") {
+		t.Errorf("NewRegistry() error = %v, want a hint to upgrade unosdk", err)
+	}
+}
+
+func TestRegistry_SavesCurrentSchema(t *testing.T) {
+	reg := newTestRegistry(t)
+	sdk := &models.SDK{Type: models.GoSDK, Provider: "golang", Version: "1.23.5", Arch: "arm64", Size: 1024, Source: models.SourceDownload}
+	if err := reg.Add(sdk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	data, err := os.ReadFile(reg.registryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("registry is invalid: %v", err)
+	}
+	if doc.SchemaVersion != SchemaVersion {
+		t.Errorf("schema_version = %d, want %d", doc.SchemaVersion, SchemaVersion)
+	}
+	got := doc.SDKs["go:golang:1.23.5"]
+	if got == nil || got.Arch != "arm64" || got.Size != 1024 || got.Source != models.SourceDownload {
+		t.Errorf("stored SDK = %+v, want arch, size and source kept", got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,189 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Shell identifies the shell code is rendered for
+type Shell string
+
+const (
+	Bash       Shell = "bash"
+	Zsh        Shell = "zsh"
+	Fish       Shell = "fish"
+	PowerShell Shell = "powershell"
+	Cmd        Shell = "cmd"
+)
+
+// Names lists the supported shells
+var Names = []Shell{Bash, Zsh, Fish, PowerShell, Cmd}
+
+// Parse returns the shell with the given name
+func Parse(name string) (Shell, error) {
+	switch strings.ToLower(name) {
+	case "bash", "sh":
+		return Bash, nil
+	case "zsh":
+		return Zsh, nil
+	case "fish":
+		return Fish, nil
+	case "powershell", "pwsh":
+		return PowerShell, nil
+	case "cmd":
+		return Cmd, nil
+	}
+	return "", fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish, powershell, cmd)", name)
+}
+
+// Detect guesses the user's shell: PowerShell on Windows, otherwise the
+// login shell from $SHELL
+func Detect() Shell {
+	if runtime.GOOS == "windows" {
+		return PowerShell
+	}
+	if sh, err := Parse(filepath.Base(os.Getenv("SHELL"))); err == nil {
+		return sh
+	}
+	return Bash
+}
+
+// Render returns code that sets the variables and replaces PATH with the
+// given entries in the current session
+func (s Shell) Render(vars map[string]string, path []string) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		s.writeVar(&b, name, vars[name])
+	}
+
+	if path == nil {
+		return b.String()
+	}
+	if s == Fish {
+		// fish keeps PATH as a list
+		b.WriteString("set -gx PATH")
+		for _, dir := range path {
+			b.WriteString(" " + fishQuote(dir))
+		}
+		b.WriteString("\n")
+	} else {
+		name := "PATH"
+		if runtime.GOOS == "windows" {
+			name = "Path"
+		}
+		s.writeVar(&b, name, strings.Join(path, string(os.PathListSeparator)))
+	}
+
+	return b.String()
+}
+
+// writeVar renders a single assignment
+func (s Shell) writeVar(b *strings.Builder, name, value string) {
+	switch s {
+	case Fish:
+		fmt.Fprintf(b, "set -gx %s %s\n", name, fishQuote(value))
+	case PowerShell:
+		fmt.Fprintf(b, "$env:%s = %s\n", name, psQuote(value))
+	case Cmd:
+		// Each line runs through for /f, which does not expand % in the value
+		fmt.Fprintf(b, "set \"%s=%s\"\n", name, value)
+	default:
+		fmt.Fprintf(b, "export %s=%s\n", name, shQuote(value))
+	}
+}
+
+// Hook returns the wrapper that makes "unosdk use" and "unosdk env" change
+// the current session. Every other command is passed through unchanged.
+func (s Shell) Hook() string {
+	switch s {
+	case Fish:
+		return `function unosdk
+    if test "$argv[1]" = use; or test "$argv[1]" = env -a (count $argv) -eq 1
+        command unosdk $argv --shell fish | source
+    else
+        command unosdk $argv
+    end
+end
+`
+	case PowerShell:
+		return `function unosdk {
+    $exe = (Get-Command unosdk -CommandType Application | Select-Object -First 1).Source
+    if (($args.Count -gt 0 -and $args[0] -eq 'use') -or ($args.Count -eq 1 -and $args[0] -eq 'env')) {
+        $out = & $exe @args --shell powershell
+        if ($LASTEXITCODE -eq 0) { Invoke-Expression ($out -join [Environment]::NewLine) }
+    } else {
+        & $exe @args
+    }
+}
+`
+	case Cmd:
+		// cmd has no functions; a doskey macro runs each printed line instead
+		return `doskey unosdk=if "$1"=="use" (for /f "delims=" %j in ('unosdk.exe $* --shell cmd') do @%j) else if "$1$2"=="env" (for /f "delims=" %j in ('unosdk.exe env --shell cmd') do @%j) else (unosdk.exe $*)
+`
+	default:
+		return fmt.Sprintf(`unosdk() {
+    if [ "$1" = use ] || { [ "$1" = env ] && [ $# -eq 1 ]; }; then
+        _unosdk_out="$(command unosdk "$@" --shell %s)" && eval "$_unosdk_out"
+        unset _unosdk_out
+    else
+        command unosdk "$@"
+    fi
+}
+`, s)
+	}
+}
+
+// Profile returns the file the hook is usually added to, for instructions
+func (s Shell) Profile() string {
+	switch s {
+	case Zsh:
+		return "~/.zshrc"
+	case Fish:
+		return "~/.config/fish/config.fish"
+	case PowerShell:
+		return "$PROFILE"
+	case Cmd:
+		return "a script run through the cmd AutoRun registry value"
+	default:
+		return "~/.bashrc"
+	}
+}
+
+// InitLine returns the line that loads the hook from a profile
+func (s Shell) InitLine() string {
+	switch s {
+	case Fish:
+		return "unosdk init fish | source"
+	case PowerShell:
+		return "unosdk init powershell | Out-String | Invoke-Expression"
+	case Cmd:
+		return "for /f \"delims=\" %%i in ('unosdk init cmd') do @%%i"
+	default:
+		return fmt.Sprintf("eval \"$(unosdk init %s)\"", s)
+	}
+}
+
+// shQuote quotes a value for POSIX shells
+func shQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes a value for fish
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// psQuote quotes a value for PowerShell
+func psQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,102 @@
+package shell
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Shell
+		wantErr bool
+	}{
+		{name: "bash", want: Bash},
+		{name: "sh", want: Bash},
+		{name: "zsh", want: Zsh},
+		{name: "fish", want: Fish},
+		{name: "pwsh", want: PowerShell},
+		{name: "PowerShell", want: PowerShell},
+		{name: "cmd", want: Cmd},
+		{name: "tcsh", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{"JAVA_HOME": "/opt/it's", "M2_HOME": "/opt/maven"}
+	path := []string{"/opt/java/bin", "/usr/bin"}
+	joined := strings.Join(path, string(os.PathListSeparator))
+	pathName := "PATH"
+	if runtime.GOOS == "windows" {
+		pathName = "Path"
+	}
+
+	tests := []struct {
+		shell Shell
+		want  string
+	}{
+		{
+			shell: Bash,
+			want:  "export JAVA_HOME='/opt/it'\\''s'\nexport M2_HOME='/opt/maven'\nexport " + pathName + "='" + joined + "'\n",
+		},
+		{
+			shell: Zsh,
+			want:  "export JAVA_HOME='/opt/it'\\''s'\nexport M2_HOME='/opt/maven'\nexport " + pathName + "='" + joined + "'\n",
+		},
+		{
+			shell: Fish,
+			want:  "set -gx JAVA_HOME '/opt/it\\'s'\nset -gx M2_HOME '/opt/maven'\nset -gx PATH '/opt/java/bin' '/usr/bin'\n",
+		},
+		{
+			shell: PowerShell,
+			want:  "$env:JAVA_HOME = '/opt/it''s'\n$env:M2_HOME = '/opt/maven'\n$env:" + pathName + " = '" + joined + "'\n",
+		},
+		{
+			shell: Cmd,
+			want:  "set \"JAVA_HOME=/opt/it's\"\nset \"M2_HOME=/opt/maven\"\nset \"" + pathName + "=" + joined + "\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.shell), func(t *testing.T) {
+			if got := tt.shell.Render(vars, path); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_WithoutPath(t *testing.T) {
+	got := Fish.Render(map[string]string{"GOROOT": "/opt/go"}, nil)
+	if want := "set -gx GOROOT '/opt/go'\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestHook(t *testing.T) {
+	for _, sh := range Names {
+		t.Run(string(sh), func(t *testing.T) {
+			hook := sh.Hook()
+			if !strings.Contains(hook, "unosdk") || !strings.Contains(hook, "use") {
+				t.Errorf("Hook() = %q, want a wrapper for unosdk use", hook)
+			}
+			if !strings.Contains(hook, "--shell "+string(sh)) {
+				t.Errorf("Hook() = %q, want it to pass --shell %s", hook, sh)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package shim
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Exec replaces the shim process with the real executable. It only returns
+// if the executable could not be started.
+func Exec(path string, args []string, env []string) (int, error) {
+	if err := syscall.Exec(path, append([]string{path}, args...), env); err != nil {
+		return 1, fmt.Errorf("failed to run %s: %w", path, err)
+	}
+	return 0, nil
+}
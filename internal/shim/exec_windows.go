@@ -0,0 +1,31 @@
+package shim
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+)
+
+// Exec runs the real executable with the shim's standard streams and returns
+// its exit code. Windows has no exec, so the shim waits for the child and
+// leaves Ctrl+C handling to it.
+func Exec(path string, args []string, env []string) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	signal.Ignore(os.Interrupt)
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 1, fmt.Errorf("failed to run %s: %w", path, err)
+	}
+	return 0, nil
+}
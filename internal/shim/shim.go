@@ -0,0 +1,251 @@
+package shim
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/javaquery/unosdk/internal/config"
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// typeOrder is the order in which SDK types are asked for a tool that
+// several of them provide (e.g. gcc from the C and C++ toolchains)
+var typeOrder = []models.SDKType{
+	models.JavaSDK,
+	models.NodeSDK,
+	models.PythonSDK,
+	models.MavenSDK,
+	models.GradleSDK,
+	models.GoSDK,
+	models.FlutterSDK,
+	models.CSDK,
+	models.CppSDK,
+}
+
+// tools lists the executables each SDK type provides a shim for
+var tools = map[models.SDKType][]string{
+	models.JavaSDK: {
+		"java", "javac", "javadoc", "jar", "jarsigner", "jshell", "jlink",
+		"jpackage", "jcmd", "jps", "jstack", "jmap", "jconsole", "keytool",
+	},
+	models.NodeSDK:    {"node", "npm", "npx", "corepack"},
+	models.PythonSDK:  {"python", "pip"},
+	models.MavenSDK:   {"mvn"},
+	models.GradleSDK:  {"gradle"},
+	models.GoSDK:      {"go", "gofmt"},
+	models.FlutterSDK: {"flutter", "dart"},
+	models.CSDK:       {"gcc", "gdb", "mingw32-make"},
+	models.CppSDK:     {"g++", "gcc", "gdb", "mingw32-make"},
+}
+
+// Dir returns the shims directory, ~/.unosdk/shims
+func Dir() (string, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(cfg.ConfigDir, "shims"), nil
+}
+
+// Enabled reports whether shims mode is on, which is the case while the
+// shims directory exists
+func Enabled() bool {
+	dir, err := Dir()
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// Tools returns the executables an SDK type provides a shim for
+func Tools(sdkType models.SDKType) []string {
+	return tools[sdkType]
+}
+
+// AllTools returns every executable a shim exists for, without duplicates
+func AllTools() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, sdkType := range typeOrder {
+		for _, tool := range tools[sdkType] {
+			if !seen[tool] {
+				seen[tool] = true
+				names = append(names, tool)
+			}
+		}
+	}
+	return names
+}
+
+// TypesFor returns the SDK types providing a tool, in lookup order
+func TypesFor(name string) []models.SDKType {
+	var types []models.SDKType
+	for _, sdkType := range typeOrder {
+		for _, tool := range tools[sdkType] {
+			if tool == name {
+				types = append(types, sdkType)
+				break
+			}
+		}
+	}
+	return types
+}
+
+// Name returns the tool a shim was started as, or "" when the executable was
+// started under any other name (i.e. as unosdk itself)
+func Name(argv0 string) string {
+	name := filepath.Base(argv0)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	}
+	if len(TypesFor(name)) == 0 {
+		return ""
+	}
+	return name
+}
+
+// Create links the unosdk executable into dir under each tool name. Hard
+// links are used where possible, so a shim costs no disk space; otherwise
+// the executable is copied.
+func Create(dir, self string, names []string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shims directory: %w", err)
+	}
+
+	for _, name := range names {
+		target := filepath.Join(dir, name+exeSuffix())
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to replace shim %s: %w", name, err)
+		}
+		if err := os.Link(self, target); err == nil {
+			continue
+		}
+		if err := copyExecutable(self, target); err != nil {
+			return fmt.Errorf("failed to create shim %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Remove deletes the shims of the given tools
+func Remove(dir string, names []string) error {
+	for _, name := range names {
+		err := os.Remove(filepath.Join(dir, name+exeSuffix()))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove shim %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// FindExecutable looks for a tool in the given directories. On Windows the
+// .exe, .cmd and .bat variants are tried, as Maven and Gradle ship batch files.
+func FindExecutable(dirs []string, name string) (string, bool) {
+	extensions := []string{""}
+	if runtime.GOOS == "windows" {
+		extensions = []string{".exe", ".cmd", ".bat"}
+	}
+
+	for _, dir := range dirs {
+		for _, ext := range extensions {
+			path := filepath.Join(dir, name+ext)
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			if runtime.GOOS != "windows" && info.Mode()&0111 == 0 {
+				continue
+			}
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Environ returns base with the SDK's variables set and its directories put
+// in front of PATH
+func Environ(base []string, vars map[string]string, dirs []string) []string {
+	env := make([]string, 0, len(base)+len(vars)+1)
+	path := ""
+	for _, entry := range base {
+		name, value, _ := strings.Cut(entry, "=")
+		if isPathVariable(name) {
+			path = value
+			continue
+		}
+		if _, ok := lookupFold(vars, name); ok {
+			continue
+		}
+		env = append(env, entry)
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+vars[name])
+	}
+
+	entries := append([]string{}, dirs...)
+	if path != "" {
+		entries = append(entries, path)
+	}
+	return append(env, "PATH="+strings.Join(entries, string(os.PathListSeparator)))
+}
+
+// lookupFold finds a variable, ignoring case on Windows where names are case-insensitive
+func lookupFold(vars map[string]string, name string) (string, bool) {
+	if runtime.GOOS != "windows" {
+		value, ok := vars[name]
+		return value, ok
+	}
+	for key, value := range vars {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// isPathVariable reports whether name refers to PATH ("Path" on Windows)
+func isPathVariable(name string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(name, "PATH")
+	}
+	return name == "PATH"
+}
+
+// exeSuffix returns the executable file extension of the platform
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
+
+// copyExecutable copies src to dst with executable permissions
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,114 @@
+package shim
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name  string
+		argv0 string
+		want  string
+	}{
+		{name: "tool", argv0: filepath.Join("home", ".unosdk", "shims", "java"), want: "java"},
+		{name: "bare tool", argv0: "mvn", want: "mvn"},
+		{name: "unosdk itself", argv0: filepath.Join("bin", "unosdk"), want: ""},
+		{name: "unknown tool", argv0: "kubectl", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Name(tt.argv0); got != tt.want {
+				t.Errorf("Name(%v) = %v, want %v", tt.argv0, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypesFor(t *testing.T) {
+	if got, want := TypesFor("gcc"), []models.SDKType{models.CSDK, models.CppSDK}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TypesFor(gcc) = %v, want %v", got, want)
+	}
+	if got := TypesFor("unosdk"); got != nil {
+		t.Errorf("TypesFor(unosdk) = %v, want nil", got)
+	}
+
+	seen := make(map[string]bool)
+	for _, tool := range AllTools() {
+		if seen[tool] {
+			t.Errorf("AllTools() lists %s twice", tool)
+		}
+		seen[tool] = true
+	}
+}
+
+func TestCreateAndRemove(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shims")
+	self := filepath.Join(t.TempDir(), "unosdk")
+	os.WriteFile(self, []byte("binary"), 0755)
+
+	if err := Create(dir, self, []string{"java", "javac"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// Creating again replaces the existing shims
+	if err := Create(dir, self, []string{"java", "javac"}); err != nil {
+		t.Fatalf("Create() again error = %v", err)
+	}
+
+	java, ok := FindExecutable([]string{dir}, "java")
+	if !ok {
+		t.Fatal("FindExecutable() should find the java shim")
+	}
+	if content, _ := os.ReadFile(java); string(content) != "binary" {
+		t.Errorf("shim content = %q, want the unosdk executable", content)
+	}
+
+	if err := Remove(dir, []string{"java", "mvn"}); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, ok := FindExecutable([]string{dir}, "java"); ok {
+		t.Error("java shim should be removed")
+	}
+	if _, ok := FindExecutable([]string{dir}, "javac"); !ok {
+		t.Error("javac shim should be kept")
+	}
+}
+
+func TestFindExecutable(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	name := "mvn"
+	if runtime.GOOS == "windows" {
+		name = "mvn.cmd"
+	}
+	os.WriteFile(filepath.Join(second, name), []byte("@echo off"), 0755)
+	if runtime.GOOS != "windows" {
+		// Files without the executable bit are skipped
+		os.WriteFile(filepath.Join(first, name), []byte("text"), 0644)
+	}
+
+	got, ok := FindExecutable([]string{first, second}, "mvn")
+	if !ok || got != filepath.Join(second, name) {
+		t.Errorf("FindExecutable() = %v, %v, want %v", got, ok, filepath.Join(second, name))
+	}
+	if _, ok := FindExecutable([]string{first, second}, "gradle"); ok {
+		t.Error("FindExecutable() should not find a missing tool")
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	base := []string{"HOME=/home/me", "JAVA_HOME=/opt/old", "PATH=/usr/bin" + sep + "/bin"}
+
+	got := Environ(base, map[string]string{"JAVA_HOME": "/sdk/jdk"}, []string{"/sdk/jdk/bin"})
+	want := []string{"HOME=/home/me", "JAVA_HOME=/sdk/jdk", "PATH=" + strings.Join([]string{"/sdk/jdk/bin", "/usr/bin", "/bin"}, sep)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Environ() = %v, want %v", got, want)
+	}
+}
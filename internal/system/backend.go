@@ -0,0 +1,56 @@
+package system
+
+import "fmt"
+
+// Scope selects between the current user's and the machine-wide environment
+type Scope int
+
+const (
+	// UserScope is the environment of the current user
+	UserScope Scope = iota
+	// SystemScope is the machine-wide environment, writable by administrators only
+	SystemScope
+)
+
+// String returns the name used for the scope in messages
+func (s Scope) String() string {
+	if s == SystemScope {
+		return "System"
+	}
+	return "User"
+}
+
+// MarshalText encodes the scope by its name
+func (s Scope) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a scope name written by MarshalText
+func (s *Scope) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "User":
+		*s = UserScope
+	case "System":
+		*s = SystemScope
+	default:
+		return fmt.Errorf("unknown scope: %s", text)
+	}
+	return nil
+}
+
+// EnvBackend persists environment variables and PATH entries. PATH is
+// addressed as "Path" or "PATH"; backends treat both the same.
+type EnvBackend interface {
+	// Get returns the value of a variable, or an error if it is not set
+	Get(scope Scope, name string) (string, error)
+	// Set sets a variable
+	Set(scope Scope, name, value string) error
+	// Delete removes a variable
+	Delete(scope Scope, name string) error
+	// PrependPath moves or adds a directory to the front of PATH
+	PrependPath(scope Scope, dir string) error
+	// RemovePath removes a directory from PATH
+	RemovePath(scope Scope, dir string) error
+	// IsAdmin reports whether the system scope is writable
+	IsAdmin() bool
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package system
+
+// NewEnvBackend creates the environment backend of the current platform
+func NewEnvBackend() EnvBackend {
+	return NewUnixEnv()
+}
@@ -0,0 +1,9 @@
+package system
+
+// NewEnvBackend creates the environment backend of the current platform
+func NewEnvBackend() EnvBackend {
+	return NewWindowsEnv()
+}
+
+// Compile-time check that the registry backend implements EnvBackend
+var _ EnvBackend = (*WindowsEnv)(nil)
@@ -0,0 +1,106 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// sdkExecutables lists the executables whose presence in a directory marks an
+// installation of each SDK type DetectSDKConflicts knows
+var sdkExecutables = map[string][]string{
+	"java":    {"java"},
+	"node":    {"node"},
+	"python":  {"python", "python3"},
+	"maven":   {"mvn"},
+	"flutter": {"flutter"},
+	"gradle":  {"gradle"},
+	"go":      {"go"},
+	"mingw":   {"gcc", "g++"},
+}
+
+// DetectSDKConflicts returns the entries of a system PATH value that belong
+// to SDK installations not managed by unosdk and would take precedence: the
+// directories that actually contain one of the SDK type's executables.
+// managed reports whether a directory belongs to unosdk; those are skipped.
+func DetectSDKConflicts(systemPath, sdkType string, managed func(string) bool) []string {
+	names, ok := sdkExecutables[sdkType]
+	if !ok {
+		return nil
+	}
+
+	var conflicts []string
+	for _, p := range strings.Split(systemPath, string(os.PathListSeparator)) {
+		pTrimmed := strings.TrimSpace(p)
+		if pTrimmed == "" {
+			continue
+		}
+
+		// The Windows System PATH may hold references like %JAVA_HOME%\bin
+		dir := pTrimmed
+		if runtime.GOOS == "windows" {
+			dir = expandPercent(dir, os.Getenv)
+		}
+
+		// Skip our own installations
+		if managed != nil && managed(dir) {
+			continue
+		}
+
+		if hasExecutable(dir, names) && !ContainsPath(conflicts, pTrimmed) {
+			conflicts = append(conflicts, pTrimmed)
+		}
+	}
+
+	return conflicts
+}
+
+// IsSystemRootPath reports whether a PATH entry lies inside the Windows
+// directory, %SystemRoot% or a subdirectory such as System32. The entry may
+// reference the directory as %SystemRoot% or %windir%.
+func IsSystemRootPath(entry string) bool {
+	lower := strings.ToLower(strings.TrimSpace(entry))
+	if strings.HasPrefix(lower, "%systemroot%") || strings.HasPrefix(lower, "%windir%") {
+		return true
+	}
+
+	dir := expandPercent(strings.TrimSpace(entry), os.Getenv)
+	for _, name := range []string{"SystemRoot", "windir"} {
+		root := os.Getenv(name)
+		if root == "" {
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			root, dir = strings.ToLower(root), strings.ToLower(dir)
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExecutable reports whether dir contains an executable with one of the
+// names, with any of the extensions Windows runs without one
+func hasExecutable(dir string, names []string) bool {
+	extensions := []string{""}
+	if runtime.GOOS == "windows" {
+		extensions = []string{".exe", ".cmd", ".bat"}
+	}
+
+	for _, name := range names {
+		for _, ext := range extensions {
+			info, err := os.Stat(filepath.Join(dir, name+ext))
+			if err != nil || info.IsDir() {
+				continue
+			}
+			if runtime.GOOS != "windows" && info.Mode()&0111 == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// newTestToolDir creates a directory holding executables with the given names
+func newTestToolDir(t *testing.T, root, dir string, names ...string) string {
+	t.Helper()
+	dir = filepath.Join(root, dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if runtime.GOOS == "windows" {
+			name += ".exe"
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("tool"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDetectSDKConflicts(t *testing.T) {
+	root := t.TempDir()
+	jdk := newTestToolDir(t, root, "Program Files/Java/jdk-17/bin", "java", "javac")
+	nodejs := newTestToolDir(t, root, "Program Files/nodejs", "node")
+	mingw := newTestToolDir(t, root, "mingw64/bin", "gcc", "g++")
+	google := newTestToolDir(t, root, "Program Files/Google/Chrome/bin", "chrome")
+	mongodb := newTestToolDir(t, root, "Program Files/MongoDB/Server/7.0/bin", "mongod")
+	configDir := filepath.Join(root, "Users/me/.unosdk")
+	unosdk := newTestToolDir(t, configDir, "java/openjdk/21/bin", "java")
+	// Only the name resembles unosdk, the installation is a foreign one
+	unosdkOld := newTestToolDir(t, root, "tools/unosdk-old/jdk/bin", "java")
+	missing := filepath.Join(root, "Program Files/Go/bin")
+
+	systemPath := testPathList(jdk, nodejs, mingw, google, mongodb, unosdk, unosdkOld, missing, "")
+	managed := func(dir string) bool {
+		rel, err := filepath.Rel(configDir, dir)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+
+	tests := []struct {
+		name    string
+		sdkType string
+		want    []string
+	}{
+		{
+			name:    "java outside unosdk",
+			sdkType: "java",
+			want:    []string{jdk, unosdkOld},
+		},
+		{
+			name:    "node",
+			sdkType: "node",
+			want:    []string{nodejs},
+		},
+		{
+			name:    "mingw",
+			sdkType: "mingw",
+			want:    []string{mingw},
+		},
+		{
+			name:    "bin directories without go",
+			sdkType: "go",
+			want:    nil,
+		},
+		{
+			name:    "no conflicts",
+			sdkType: "maven",
+			want:    nil,
+		},
+		{
+			name:    "unknown type",
+			sdkType: "unknown",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectSDKConflicts(systemPath, tt.sdkType, managed)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectSDKConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectSDKConflicts_NotExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no executable bit")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := DetectSDKConflicts(dir, "go", nil); got != nil {
+		t.Errorf("DetectSDKConflicts() = %v, want nil", got)
+	}
+}
+
+func TestIsSystemRootPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "Windows")
+	t.Setenv("SystemRoot", root)
+	t.Setenv("windir", root)
+
+	tests := []struct {
+		name  string
+		entry string
+		want  bool
+	}{
+		{"windows directory", root, true},
+		{"system32", filepath.Join(root, "System32"), true},
+		{"nested", filepath.Join(root, "System32", "Wbem"), true},
+		{"variable reference", "%SystemRoot%" + string(filepath.Separator) + "System32", true},
+		{"windir reference", "%WINDIR%", true},
+		{"sibling with the same prefix", root + "Apps", false},
+		{"program files", filepath.Join(filepath.Dir(root), "Program Files", "Java", "bin"), false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSystemRootPath(tt.entry); got != tt.want {
+				t.Errorf("IsSystemRootPath(%q) = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
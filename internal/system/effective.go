@@ -0,0 +1,65 @@
+package system
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// EffectivePath returns the PATH entries a new terminal starts with, in lookup
+// order and without duplicates. On Windows that is the System PATH followed
+// by the User PATH; elsewhere the PATH managed by unosdk comes first, followed
+// by the PATH of the current process.
+func EffectivePath(backend EnvBackend) []string {
+	var entries []string
+	add := func(value string) {
+		for _, entry := range splitPath(value) {
+			if !ContainsPath(entries, entry) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		for _, scope := range []Scope{SystemScope, UserScope} {
+			if value, err := backend.Get(scope, "Path"); err == nil {
+				add(expandPercent(value, os.Getenv))
+			}
+		}
+		return entries
+	}
+
+	if value, err := backend.Get(UserScope, "Path"); err == nil {
+		add(value)
+	}
+	add(os.Getenv("PATH"))
+	return entries
+}
+
+// expandPercent replaces Windows %NAME% references, as found in REG_EXPAND_SZ
+// values, using getenv. Unknown names are left as they are.
+func expandPercent(value string, getenv func(string) string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(value, '%')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start+1:], '%')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+
+		name := value[start+1 : end]
+		if expanded := getenv(name); name != "" && expanded != "" {
+			b.WriteString(value[:start] + expanded)
+			value = value[end+1:]
+		} else {
+			b.WriteString(value[:end])
+			value = value[end:]
+		}
+	}
+	b.WriteString(value)
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package system
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestEffectivePath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Windows order is System then User, read from the registry")
+	}
+
+	env := NewMemoryEnv()
+	if err := env.PrependPath(UserScope, testDir("java-bin")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", testPathList(testDir("usr-bin"), testDir("java-bin")))
+
+	want := []string{testDir("java-bin"), testDir("usr-bin")}
+	if got := EffectivePath(env); !reflect.DeepEqual(got, want) {
+		t.Errorf("EffectivePath() = %v, want %v", got, want)
+	}
+}
+
+func TestExpandPercent(t *testing.T) {
+	getenv := func(name string) string {
+		return map[string]string{"SystemRoot": `C:\Windows`, "USERPROFILE": `C:\Users\dev`}[name]
+	}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: `%SystemRoot%\system32`, want: `C:\Windows\system32`},
+		{value: `%USERPROFILE%\.unosdk\shims`, want: `C:\Users\dev\.unosdk\shims`},
+		{value: `%UNKNOWN%\bin`, want: `%UNKNOWN%\bin`},
+		{value: `100%`, want: `100%`},
+		{value: `%%SystemRoot%`, want: `%C:\Windows`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := expandPercent(tt.value, getenv); got != tt.want {
+				t.Errorf("expandPercent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
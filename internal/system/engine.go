@@ -0,0 +1,236 @@
+package system
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+// ChangeKind identifies what an environment change does
+type ChangeKind int
+
+const (
+	// ChangeSetVar sets a variable
+	ChangeSetVar ChangeKind = iota
+	// ChangeDeleteVar deletes a variable
+	ChangeDeleteVar
+	// ChangeAddPath adds a directory to PATH
+	ChangeAddPath
+	// ChangeRemovePath removes a directory from PATH
+	ChangeRemovePath
+	// ChangeKeepVar records a variable left alone because it is not managed by unosdk
+	ChangeKeepVar
+)
+
+// Change is a single modification of the environment
+type Change struct {
+	Kind  ChangeKind
+	Scope Scope
+	// Name is the variable name, empty for PATH changes
+	Name string
+	// Value is the variable value or the PATH directory
+	Value string
+	// Err is set when an optional System scope change failed
+	Err error
+}
+
+// String describes the change for command output
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeSetVar:
+		return fmt.Sprintf("Set %s %s=%s", c.Scope, c.Name, c.Value)
+	case ChangeDeleteVar:
+		return fmt.Sprintf("Removed %s %s", c.Scope, c.Name)
+	case ChangeAddPath:
+		return fmt.Sprintf("Added to %s PATH: %s", c.Scope, c.Value)
+	case ChangeRemovePath:
+		return fmt.Sprintf("Removed from %s PATH: %s", c.Scope, c.Value)
+	case ChangeKeepVar:
+		return fmt.Sprintf("Kept %s %s=%s, it is not managed by unosdk", c.Scope, c.Name, c.Value)
+	}
+	return ""
+}
+
+// EnvEngine applies, removes and diffs SDK environment specs. The User scope
+// is always updated and its failures are returned as errors. When running as
+// administrator the System scope is updated as well; failures there are
+// recorded on the change instead.
+type EnvEngine struct {
+	// Managed reports whether a variable value belongs to unosdk. Variables
+	// holding any other value are neither overwritten nor removed. A nil
+	// Managed treats every value as managed.
+	Managed func(value string) bool
+
+	backend EnvBackend
+}
+
+// NewEnvEngine creates an engine writing to the given backend
+func NewEnvEngine(backend EnvBackend) *EnvEngine {
+	return &EnvEngine{backend: backend}
+}
+
+// Diff returns the User scope changes needed to make the spec active. An
+// empty result means the SDK is the active one.
+func (e *EnvEngine) Diff(spec models.EnvSpec, installPath string) []Change {
+	vars, dirs := spec.Expand(installPath)
+
+	var changes []Change
+	for _, name := range sortedNames(vars) {
+		current, writable := e.writable(UserScope, name)
+		if writable && current != vars[name] {
+			changes = append(changes, Change{Kind: ChangeSetVar, Scope: UserScope, Name: name, Value: vars[name]})
+		}
+	}
+
+	path := e.pathEntries(UserScope)
+	for _, dir := range dirs {
+		if !ContainsPath(path, dir) {
+			changes = append(changes, Change{Kind: ChangeAddPath, Scope: UserScope, Value: dir})
+		}
+	}
+
+	return changes
+}
+
+// Apply puts the spec's PATH directories in front of PATH, keeping their
+// declared order, and sets its variables if setVars is true
+func (e *EnvEngine) Apply(spec models.EnvSpec, installPath string, setVars bool) ([]Change, error) {
+	vars, dirs := spec.Expand(installPath)
+	if !setVars {
+		vars = nil
+	}
+
+	var changes []Change
+	for _, scope := range e.scopes() {
+		for _, name := range sortedNames(vars) {
+			if current, writable := e.writable(scope, name); !writable {
+				changes = append(changes, Change{Kind: ChangeKeepVar, Scope: scope, Name: name, Value: current})
+				continue
+			}
+			change := Change{Kind: ChangeSetVar, Scope: scope, Name: name, Value: vars[name]}
+			if err := e.backend.Set(scope, name, vars[name]); err != nil {
+				if scope == UserScope {
+					return changes, fmt.Errorf("failed to set %s %s: %w", scope, name, err)
+				}
+				change.Err = err
+			}
+			changes = append(changes, change)
+		}
+
+		// Prepending in reverse leaves the first declared directory in front
+		pathChanges := make([]Change, len(dirs))
+		for i := len(dirs) - 1; i >= 0; i-- {
+			change := Change{Kind: ChangeAddPath, Scope: scope, Value: dirs[i]}
+			if err := e.backend.PrependPath(scope, dirs[i]); err != nil {
+				if scope == UserScope {
+					return changes, fmt.Errorf("failed to add to %s PATH: %w", scope, err)
+				}
+				change.Err = err
+			}
+			pathChanges[i] = change
+		}
+		changes = append(changes, pathChanges...)
+	}
+
+	return changes, nil
+}
+
+// Remove takes the spec's PATH directories out of PATH and deletes its
+// variables while they still hold the values the spec sets
+func (e *EnvEngine) Remove(spec models.EnvSpec, installPath string) ([]Change, error) {
+	vars, dirs := spec.Expand(installPath)
+
+	var changes []Change
+	for _, scope := range e.scopes() {
+		for _, name := range sortedNames(vars) {
+			if current, err := e.backend.Get(scope, name); err != nil || current != vars[name] {
+				continue
+			}
+			change := Change{Kind: ChangeDeleteVar, Scope: scope, Name: name}
+			if err := e.backend.Delete(scope, name); err != nil {
+				if scope == UserScope {
+					return changes, fmt.Errorf("failed to remove %s %s: %w", scope, name, err)
+				}
+				change.Err = err
+			}
+			changes = append(changes, change)
+		}
+
+		path := e.pathEntries(scope)
+		for _, dir := range dirs {
+			if !ContainsPath(path, dir) {
+				continue
+			}
+			change := Change{Kind: ChangeRemovePath, Scope: scope, Value: dir}
+			if err := e.backend.RemovePath(scope, dir); err != nil {
+				if scope == UserScope {
+					return changes, fmt.Errorf("failed to remove from %s PATH: %w", scope, err)
+				}
+				change.Err = err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// writable returns the current value of a variable and whether the engine may
+// overwrite it: it is unset, or holds a value managed by unosdk
+func (e *EnvEngine) writable(scope Scope, name string) (string, bool) {
+	current, err := e.backend.Get(scope, name)
+	if err != nil || current == "" {
+		return "", true
+	}
+	return current, e.Managed == nil || e.Managed(current)
+}
+
+// scopes returns the scopes the engine writes to
+func (e *EnvEngine) scopes() []Scope {
+	if e.backend.IsAdmin() {
+		return []Scope{UserScope, SystemScope}
+	}
+	return []Scope{UserScope}
+}
+
+// pathEntries returns the PATH entries of a scope
+func (e *EnvEngine) pathEntries(scope Scope) []string {
+	value, err := e.backend.Get(scope, "Path")
+	if err != nil {
+		return nil
+	}
+	return splitPath(value)
+}
+
+// ContainsPath reports whether dir is one of the PATH entries
+func ContainsPath(entries []string, dir string) bool {
+	for _, entry := range entries {
+		if samePath(entry, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// samePath compares two directories, ignoring case on Windows
+func samePath(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// sortedNames returns the variable names in a stable order
+func sortedNames(vars map[string]string) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
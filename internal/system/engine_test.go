@@ -0,0 +1,156 @@
+package system
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/javaquery/unosdk/pkg/models"
+)
+
+func testSpec() models.EnvSpec {
+	return models.EnvSpec{
+		Vars: map[string]string{"PYTHON_HOME": models.InstallPathPlaceholder},
+		Path: []string{".", "Scripts"},
+	}
+}
+
+func TestEnvEngine_Apply(t *testing.T) {
+	env := NewMemoryEnv()
+	engine := NewEnvEngine(env)
+	installPath := testDir("python")
+	other := testDir("other")
+	env.PrependPath(UserScope, other)
+
+	changes, err := engine.Apply(testSpec(), installPath, true)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("Apply() made %d changes, want 3: %v", len(changes), changes)
+	}
+
+	// Declared order is kept in front of the existing entries
+	want := []string{installPath, filepath.Join(installPath, "Scripts"), other}
+	if got := env.PathEntries(UserScope); !reflect.DeepEqual(got, want) {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+	if got, _ := env.Get(UserScope, "PYTHON_HOME"); got != installPath {
+		t.Errorf("PYTHON_HOME = %v, want %v", got, installPath)
+	}
+	if diff := engine.Diff(testSpec(), installPath); len(diff) != 0 {
+		t.Errorf("Diff() after Apply() = %v, want none", diff)
+	}
+}
+
+func TestEnvEngine_ApplyWithoutVars(t *testing.T) {
+	env := NewMemoryEnv()
+	engine := NewEnvEngine(env)
+	installPath := testDir("python")
+
+	if _, err := engine.Apply(testSpec(), installPath, false); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if _, err := env.Get(UserScope, "PYTHON_HOME"); err == nil {
+		t.Error("Apply() should not set variables when setVars is false")
+	}
+
+	diff := engine.Diff(testSpec(), installPath)
+	want := []Change{{Kind: ChangeSetVar, Scope: UserScope, Name: "PYTHON_HOME", Value: installPath}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Diff() = %v, want %v", diff, want)
+	}
+}
+
+func TestEnvEngine_Remove(t *testing.T) {
+	env := NewMemoryEnv()
+	engine := NewEnvEngine(env)
+	installPath := testDir("python")
+	engine.Apply(testSpec(), installPath, true)
+
+	// Another SDK took over the variable, so it must be kept
+	env.Set(UserScope, "PYTHON_HOME", testDir("other"))
+
+	changes, err := engine.Remove(testSpec(), installPath)
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	for _, change := range changes {
+		if change.Kind != ChangeRemovePath {
+			t.Errorf("Remove() made unexpected change %v", change)
+		}
+	}
+	if got := env.PathEntries(UserScope); len(got) != 0 {
+		t.Errorf("PATH = %v, want empty", got)
+	}
+	if got, _ := env.Get(UserScope, "PYTHON_HOME"); got != testDir("other") {
+		t.Errorf("PYTHON_HOME = %v, want %v", got, testDir("other"))
+	}
+}
+
+func TestEnvEngine_SystemScope(t *testing.T) {
+	env := NewMemoryEnv()
+	env.Admin = true
+	engine := NewEnvEngine(env)
+	installPath := testDir("python")
+
+	if _, err := engine.Apply(testSpec(), installPath, true); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got, _ := env.Get(SystemScope, "PYTHON_HOME"); got != installPath {
+		t.Errorf("System PYTHON_HOME = %v, want %v", got, installPath)
+	}
+
+	if _, err := engine.Remove(testSpec(), installPath); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if got := env.PathEntries(SystemScope); len(got) != 0 {
+		t.Errorf("System PATH = %v, want empty", got)
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{Change{Kind: ChangeSetVar, Scope: UserScope, Name: "JAVA_HOME", Value: "jdk"}, "Set User JAVA_HOME=jdk"},
+		{Change{Kind: ChangeDeleteVar, Scope: SystemScope, Name: "JAVA_HOME"}, "Removed System JAVA_HOME"},
+		{Change{Kind: ChangeAddPath, Scope: UserScope, Value: "bin"}, "Added to User PATH: bin"},
+		{Change{Kind: ChangeRemovePath, Scope: UserScope, Value: "bin"}, "Removed from User PATH: bin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.change.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvEngine_Managed(t *testing.T) {
+	env := NewMemoryEnv()
+	engine := NewEnvEngine(env)
+	engine.Managed = func(value string) bool { return value != testDir("foreign") }
+	installPath := testDir("python")
+
+	env.Set(UserScope, "PYTHON_HOME", testDir("foreign"))
+
+	changes, err := engine.Apply(testSpec(), installPath, true)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	want := Change{Kind: ChangeKeepVar, Scope: UserScope, Name: "PYTHON_HOME", Value: testDir("foreign")}
+	if len(changes) == 0 || changes[0] != want {
+		t.Errorf("Apply() changes = %v, want %v first", changes, want)
+	}
+	if got, _ := env.Get(UserScope, "PYTHON_HOME"); got != testDir("foreign") {
+		t.Errorf("PYTHON_HOME = %v, want it kept", got)
+	}
+
+	// A foreign variable does not keep the SDK from being the active one
+	if diff := engine.Diff(testSpec(), installPath); len(diff) != 0 {
+		t.Errorf("Diff() = %v, want none", diff)
+	}
+}
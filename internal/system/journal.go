@@ -0,0 +1,307 @@
+package system
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Names of the files JournaledEnv keeps in its directory
+const (
+	// JournalFile lists every environment change, one JSON entry per line
+	JournalFile = "env-journal.jsonl"
+	// PathSnapshotFile holds the PATH from before the first change
+	PathSnapshotFile = "path-snapshot.json"
+)
+
+// JournalEntry records a change to one environment variable. Old and New are
+// nil while the variable is not set.
+type JournalEntry struct {
+	// Run identifies the unosdk invocation that made the change
+	Run     string    `json:"run"`
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Scope   Scope     `json:"scope"`
+	Name    string    `json:"name"`
+	Old     *string   `json:"old"`
+	New     *string   `json:"new"`
+	// Undoes is the run whose changes this entry reverts
+	Undoes string `json:"undoes,omitempty"`
+}
+
+// PathSnapshot is the PATH of each scope before unosdk first changed the
+// environment. A scope is nil when its PATH was not set or not readable.
+type PathSnapshot struct {
+	Time   time.Time `json:"time"`
+	User   *string   `json:"user"`
+	System *string   `json:"system"`
+}
+
+// Compile-time check that JournaledEnv implements EnvBackend
+var _ EnvBackend = (*JournaledEnv)(nil)
+
+// JournaledEnv is an EnvBackend that records every change made through it in
+// the journal, and snapshots PATH before the first change ever made
+type JournaledEnv struct {
+	EnvBackend
+
+	// Undoes marks the changes as reverting an earlier run
+	Undoes string
+
+	dir     string
+	command string
+	run     string
+}
+
+// NewJournaledEnv wraps a backend so that its changes are recorded in dir,
+// attributed to the run and command that make them
+func NewJournaledEnv(backend EnvBackend, dir, run, command string) *JournaledEnv {
+	return &JournaledEnv{
+		EnvBackend: backend,
+		dir:        dir,
+		command:    command,
+		run:        run,
+	}
+}
+
+// NewRunID returns an identifier for the changes of one unosdk invocation
+func NewRunID() string {
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), os.Getpid())
+}
+
+// Set sets a variable and records the change
+func (j *JournaledEnv) Set(scope Scope, name, value string) error {
+	return j.record(scope, name, func() error { return j.EnvBackend.Set(scope, name, value) })
+}
+
+// Delete removes a variable and records the change
+func (j *JournaledEnv) Delete(scope Scope, name string) error {
+	return j.record(scope, name, func() error { return j.EnvBackend.Delete(scope, name) })
+}
+
+// PrependPath moves or adds a directory to the front of PATH and records the
+// change
+func (j *JournaledEnv) PrependPath(scope Scope, dir string) error {
+	return j.record(scope, "PATH", func() error { return j.EnvBackend.PrependPath(scope, dir) })
+}
+
+// RemovePath removes a directory from PATH and records the change
+func (j *JournaledEnv) RemovePath(scope Scope, dir string) error {
+	return j.record(scope, "PATH", func() error { return j.EnvBackend.RemovePath(scope, dir) })
+}
+
+// Lookup returns the value of a variable, or nil if it is not set
+func (j *JournaledEnv) Lookup(scope Scope, name string) *string {
+	value, err := j.EnvBackend.Get(scope, name)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// Undo restores the values the variables changed by a run had before it,
+// recording the changes as reverting the run. Unless force is set, it fails
+// without changing anything when a variable was changed again since.
+func (j *JournaledEnv) Undo(run *JournalRun, force bool) error {
+	if !force {
+		checked := make(map[string]bool)
+		for i := len(run.Entries) - 1; i >= 0; i-- {
+			entry := run.Entries[i]
+			key := entry.Scope.String() + " " + strings.ToUpper(entry.Name)
+			if checked[key] {
+				continue
+			}
+			checked[key] = true
+			if !equalValues(j.Lookup(entry.Scope, entry.Name), entry.New) {
+				return fmt.Errorf("%s %s was changed again after '%s'", entry.Scope, entry.Name, run.Command)
+			}
+		}
+	}
+
+	previous := j.Undoes
+	j.Undoes = run.ID
+	defer func() { j.Undoes = previous }()
+
+	for i := len(run.Entries) - 1; i >= 0; i-- {
+		entry := run.Entries[i]
+		var err error
+		switch {
+		case entry.Old != nil:
+			err = j.Set(entry.Scope, entry.Name, *entry.Old)
+		case j.Lookup(entry.Scope, entry.Name) != nil:
+			err = j.Delete(entry.Scope, entry.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore %s %s: %w", entry.Scope, entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// record runs mutate and appends the resulting change of the variable to
+// the journal. Changes a failing mutate made before failing are recorded too.
+func (j *JournaledEnv) record(scope Scope, name string, mutate func() error) error {
+	if err := j.snapshot(); err != nil {
+		return fmt.Errorf("failed to snapshot PATH: %w", err)
+	}
+
+	old := j.Lookup(scope, name)
+	err := mutate()
+	current := j.Lookup(scope, name)
+	if equalValues(old, current) {
+		return err
+	}
+
+	entry := JournalEntry{
+		Run:     j.run,
+		Time:    time.Now(),
+		Command: j.command,
+		Scope:   scope,
+		Name:    name,
+		Old:     old,
+		New:     current,
+		Undoes:  j.Undoes,
+	}
+	if journalErr := appendJournal(j.dir, entry); journalErr != nil && err == nil {
+		return fmt.Errorf("failed to record environment change: %w", journalErr)
+	}
+	return err
+}
+
+// snapshot saves the PATH of both scopes unless a snapshot already exists
+func (j *JournaledEnv) snapshot() error {
+	path := filepath.Join(j.dir, PathSnapshotFile)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return err
+	}
+
+	data, err := json.MarshalIndent(PathSnapshot{
+		Time:   time.Now(),
+		User:   j.Lookup(UserScope, "PATH"),
+		System: j.Lookup(SystemScope, "PATH"),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(j.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// appendJournal appends an entry to the journal in dir
+func appendJournal(dir string, entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// Appends of a single line are not interleaved with other processes
+	f, err := os.OpenFile(filepath.Join(dir, JournalFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadJournal returns the entries of the journal in dir, oldest first
+func ReadJournal(dir string) ([]JournalEntry, error) {
+	f, err := os.Open(filepath.Join(dir, JournalFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open environment journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid environment journal entry on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read environment journal: %w", err)
+	}
+	return entries, nil
+}
+
+// ReadPathSnapshot returns the PATH snapshot in dir, or nil if none was taken
+func ReadPathSnapshot(dir string) (*PathSnapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PathSnapshotFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PATH snapshot: %w", err)
+	}
+
+	var snapshot PathSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid PATH snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// JournalRun groups the journal entries of one unosdk invocation
+type JournalRun struct {
+	ID      string
+	Time    time.Time
+	Command string
+	Entries []JournalEntry
+	// Undoes is the run this one reverted, Undone whether a later run
+	// reverted this one
+	Undoes string
+	Undone bool
+}
+
+// GroupRuns groups journal entries by run, oldest first. An undo that
+// reverts several runs yields one group per reverted run.
+func GroupRuns(entries []JournalEntry) []*JournalRun {
+	var runs []*JournalRun
+	byKey := make(map[string]*JournalRun)
+	for _, entry := range entries {
+		key := entry.Run + " " + entry.Undoes
+		run, ok := byKey[key]
+		if !ok {
+			run = &JournalRun{ID: entry.Run, Time: entry.Time, Command: entry.Command, Undoes: entry.Undoes}
+			byKey[key] = run
+			runs = append(runs, run)
+		}
+		run.Entries = append(run.Entries, entry)
+	}
+
+	for _, run := range runs {
+		if undone, ok := byKey[run.Undoes+" "]; ok && run.Undoes != "" {
+			undone.Undone = true
+		}
+	}
+	return runs
+}
+
+// equalValues reports whether two optional values are the same
+func equalValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
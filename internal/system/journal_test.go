@@ -0,0 +1,122 @@
+package system
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJournaledEnv_Records(t *testing.T) {
+	dir := t.TempDir()
+	mem := NewMemoryEnv()
+	mem.PrependPath(UserScope, testDir("usr-bin"))
+	env := NewJournaledEnv(mem, dir, NewRunID(), "unosdk install java openjdk 21")
+
+	env.Set(UserScope, "JAVA_HOME", "/opt/jdk-21")
+	env.PrependPath(UserScope, testDir("jdk-21"))
+	// Unchanged values are not recorded
+	env.PrependPath(UserScope, testDir("jdk-21"))
+	env.Delete(UserScope, "JAVA_HOME")
+
+	entries, err := ReadJournal(dir)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("ReadJournal() = %d entries, want 3", len(entries))
+	}
+
+	jdk := "/opt/jdk-21"
+	oldPath, newPath := testDir("usr-bin"), testPathList(testDir("jdk-21"), testDir("usr-bin"))
+	tests := []struct {
+		name string
+		old  *string
+		new  *string
+	}{
+		{name: "JAVA_HOME", new: &jdk},
+		{name: "PATH", old: &oldPath, new: &newPath},
+		{name: "JAVA_HOME", old: &jdk},
+	}
+	for i, tt := range tests {
+		got := entries[i]
+		if got.Name != tt.name || got.Scope != UserScope || !reflect.DeepEqual(got.Old, tt.old) || !reflect.DeepEqual(got.New, tt.new) {
+			t.Errorf("entry %d = %+v, want %s %v -> %v", i, got, tt.name, tt.old, tt.new)
+		}
+		if got.Command != "unosdk install java openjdk 21" || got.Run != entries[0].Run {
+			t.Errorf("entry %d = %+v, want it attributed to the same run", i, got)
+		}
+	}
+
+	// The snapshot holds PATH from before the first change
+	snapshot, err := ReadPathSnapshot(dir)
+	if err != nil {
+		t.Fatalf("ReadPathSnapshot() error = %v", err)
+	}
+	if snapshot == nil || snapshot.User == nil || *snapshot.User != oldPath || snapshot.System != nil {
+		t.Errorf("ReadPathSnapshot() = %+v, want User PATH %v", snapshot, oldPath)
+	}
+
+	// Later runs keep the first snapshot
+	NewJournaledEnv(mem, dir, NewRunID(), "unosdk switch").PrependPath(UserScope, testDir("jdk-17"))
+	if again, _ := ReadPathSnapshot(dir); *again.User != oldPath {
+		t.Errorf("snapshot User PATH = %v, want %v", *again.User, oldPath)
+	}
+}
+
+func TestJournaledEnv_Undo(t *testing.T) {
+	dir := t.TempDir()
+	mem := NewMemoryEnv()
+	mem.Set(UserScope, "JAVA_HOME", "/opt/jdk-17")
+
+	install := NewJournaledEnv(mem, dir, NewRunID(), "unosdk switch java openjdk 21")
+	install.Set(UserScope, "JAVA_HOME", "/opt/jdk-21")
+	install.PrependPath(UserScope, testDir("jdk-21"))
+
+	entries, _ := ReadJournal(dir)
+	runs := GroupRuns(entries)
+	if len(runs) != 1 || len(runs[0].Entries) != 2 {
+		t.Fatalf("GroupRuns() = %+v, want one run with two entries", runs)
+	}
+
+	undo := NewJournaledEnv(mem, dir, NewRunID(), "unosdk env undo")
+	if err := undo.Undo(runs[0], false); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if got, _ := mem.Get(UserScope, "JAVA_HOME"); got != "/opt/jdk-17" {
+		t.Errorf("JAVA_HOME = %v, want /opt/jdk-17", got)
+	}
+	if _, err := mem.Get(UserScope, "PATH"); err == nil {
+		t.Error("PATH should be unset again")
+	}
+
+	entries, _ = ReadJournal(dir)
+	runs = GroupRuns(entries)
+	if len(runs) != 2 || !runs[0].Undone || runs[1].Undoes != runs[0].ID {
+		t.Errorf("GroupRuns() = %+v, want the first run undone by the second", runs)
+	}
+}
+
+func TestJournaledEnv_UndoChangedSince(t *testing.T) {
+	dir := t.TempDir()
+	mem := NewMemoryEnv()
+
+	NewJournaledEnv(mem, dir, NewRunID(), "unosdk switch").Set(UserScope, "JAVA_HOME", "/opt/jdk-21")
+	mem.Set(UserScope, "JAVA_HOME", "/opt/manual")
+
+	entries, _ := ReadJournal(dir)
+	run := GroupRuns(entries)[0]
+	undo := NewJournaledEnv(mem, dir, NewRunID(), "unosdk env undo")
+
+	if err := undo.Undo(run, false); err == nil {
+		t.Error("Undo() should fail for a variable changed since")
+	}
+	if got, _ := mem.Get(UserScope, "JAVA_HOME"); got != "/opt/manual" {
+		t.Errorf("JAVA_HOME = %v, want it unchanged", got)
+	}
+
+	if err := undo.Undo(run, true); err != nil {
+		t.Fatalf("Undo(force) error = %v", err)
+	}
+	if _, err := mem.Get(UserScope, "JAVA_HOME"); err == nil {
+		t.Error("JAVA_HOME should be unset after a forced undo")
+	}
+}
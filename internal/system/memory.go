@@ -0,0 +1,120 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Compile-time checks that the portable backends implement EnvBackend
+var (
+	_ EnvBackend = (*UnixEnv)(nil)
+	_ EnvBackend = (*MemoryEnv)(nil)
+)
+
+// MemoryEnv is an EnvBackend that keeps variables in memory. It is used in
+// tests to exercise environment setup without touching the real environment.
+type MemoryEnv struct {
+	// Admin makes the system scope writable
+	Admin bool
+
+	vars map[Scope]map[string]string
+}
+
+// NewMemoryEnv creates an empty in-memory environment
+func NewMemoryEnv() *MemoryEnv {
+	return &MemoryEnv{
+		vars: map[Scope]map[string]string{
+			UserScope:   {},
+			SystemScope: {},
+		},
+	}
+}
+
+// Get returns the value of a variable
+func (m *MemoryEnv) Get(scope Scope, name string) (string, error) {
+	value, ok := m.vars[scope][memoryKey(name)]
+	if !ok {
+		return "", fmt.Errorf("failed to get environment variable: %s is not set", name)
+	}
+	return value, nil
+}
+
+// Set sets a variable
+func (m *MemoryEnv) Set(scope Scope, name, value string) error {
+	if err := m.checkScope(scope); err != nil {
+		return err
+	}
+	m.vars[scope][memoryKey(name)] = value
+	return nil
+}
+
+// Delete removes a variable
+func (m *MemoryEnv) Delete(scope Scope, name string) error {
+	if err := m.checkScope(scope); err != nil {
+		return err
+	}
+	key := memoryKey(name)
+	if _, ok := m.vars[scope][key]; !ok {
+		return fmt.Errorf("failed to delete environment variable: %s is not set", name)
+	}
+	delete(m.vars[scope], key)
+	return nil
+}
+
+// PrependPath moves or adds a directory to the front of PATH
+func (m *MemoryEnv) PrependPath(scope Scope, dir string) error {
+	if err := m.checkScope(scope); err != nil {
+		return err
+	}
+	entries := append([]string{dir}, m.removePath(scope, dir)...)
+	m.vars[scope]["PATH"] = strings.Join(entries, string(os.PathListSeparator))
+	return nil
+}
+
+// RemovePath removes a directory from PATH
+func (m *MemoryEnv) RemovePath(scope Scope, dir string) error {
+	if err := m.checkScope(scope); err != nil {
+		return err
+	}
+	m.vars[scope]["PATH"] = strings.Join(m.removePath(scope, dir), string(os.PathListSeparator))
+	return nil
+}
+
+// IsAdmin reports whether the system scope is writable
+func (m *MemoryEnv) IsAdmin() bool {
+	return m.Admin
+}
+
+// PathEntries returns the PATH entries of a scope in order
+func (m *MemoryEnv) PathEntries(scope Scope) []string {
+	return splitPath(m.vars[scope]["PATH"])
+}
+
+// removePath returns the PATH entries of a scope without dir
+func (m *MemoryEnv) removePath(scope Scope, dir string) []string {
+	var kept []string
+	for _, entry := range m.PathEntries(scope) {
+		if !strings.EqualFold(filepath.Clean(entry), filepath.Clean(dir)) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// checkScope rejects writes to the system scope unless Admin is set
+func (m *MemoryEnv) checkScope(scope Scope) error {
+	if scope == SystemScope && !m.Admin {
+		return fmt.Errorf("failed to modify %s environment: admin rights required", scope)
+	}
+	return nil
+}
+
+// memoryKey normalizes PATH, which Windows spells "Path"
+func memoryKey(name string) string {
+	if isPathVariable(name) {
+		return "PATH"
+	}
+	return name
+}
@@ -0,0 +1,67 @@
+package system
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryEnv_Variables(t *testing.T) {
+	env := NewMemoryEnv()
+
+	if err := env.Set(UserScope, "JAVA_HOME", "/opt/jdk"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, _ := env.Get(UserScope, "JAVA_HOME"); got != "/opt/jdk" {
+		t.Errorf("Get() = %v, want %v", got, "/opt/jdk")
+	}
+	if _, err := env.Get(SystemScope, "JAVA_HOME"); err == nil {
+		t.Error("Get() should not see user variables in the system scope")
+	}
+
+	if err := env.Delete(UserScope, "JAVA_HOME"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := env.Delete(UserScope, "JAVA_HOME"); err == nil {
+		t.Error("Delete() should fail for an unset variable")
+	}
+}
+
+func TestMemoryEnv_Path(t *testing.T) {
+	env := NewMemoryEnv()
+	a, b := testDir("a"), testDir("b")
+
+	env.PrependPath(UserScope, a)
+	env.PrependPath(UserScope, b)
+	env.PrependPath(UserScope, a)
+
+	if got := env.PathEntries(UserScope); !reflect.DeepEqual(got, []string{a, b}) {
+		t.Errorf("PathEntries() = %v, want %v", got, []string{a, b})
+	}
+
+	// "Path" and "PATH" name the same variable
+	if got, _ := env.Get(UserScope, "Path"); got != testPathList(a, b) {
+		t.Errorf("Get(Path) = %v, want %v", got, testPathList(a, b))
+	}
+
+	env.RemovePath(UserScope, a+string(filepath.Separator))
+	if got := env.PathEntries(UserScope); !reflect.DeepEqual(got, []string{b}) {
+		t.Errorf("PathEntries() = %v, want %v", got, []string{b})
+	}
+}
+
+func TestMemoryEnv_SystemScope(t *testing.T) {
+	env := NewMemoryEnv()
+
+	if err := env.Set(SystemScope, "JAVA_HOME", "/opt/jdk"); err == nil {
+		t.Error("Set() should fail for the system scope without admin rights")
+	}
+	if err := env.PrependPath(SystemScope, testDir("a")); err == nil {
+		t.Error("PrependPath() should fail for the system scope without admin rights")
+	}
+
+	env.Admin = true
+	if err := env.Set(SystemScope, "JAVA_HOME", "/opt/jdk"); err != nil {
+		t.Errorf("Set() error = %v", err)
+	}
+}
@@ -17,14 +17,12 @@ func NewPathManager() *PathManager {
 
 // AddToPath adds a directory to the system PATH
 func (pm *PathManager) AddToPath(dir string) error {
-	env := NewWindowsEnv()
-	return env.AddToPath(dir)
+	return NewEnvBackend().PrependPath(UserScope, dir)
 }
 
 // RemoveFromPath removes a directory from the system PATH
 func (pm *PathManager) RemoveFromPath(dir string) error {
-	env := NewWindowsEnv()
-	return env.RemoveFromPath(dir)
+	return NewEnvBackend().RemovePath(UserScope, dir)
 }
 
 // IsInPath checks if a directory is in the PATH
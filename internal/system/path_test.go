@@ -7,6 +7,16 @@ import (
 	"testing"
 )
 
+// testDir returns a directory below a fake root, using the platform's separators
+func testDir(name string) string {
+	return filepath.Join(string(filepath.Separator)+"test", name)
+}
+
+// testPathList joins directories with the platform's PATH list separator
+func testPathList(dirs ...string) string {
+	return strings.Join(dirs, string(os.PathListSeparator))
+}
+
 func TestNewPathManager(t *testing.T) {
 	pm := NewPathManager()
 	if pm == nil {
@@ -22,7 +32,7 @@ func TestPathManager_IsInPath(t *testing.T) {
 	defer os.Setenv("PATH", originalPath)
 	
 	// Set a test PATH
-	testPath := "C:\\test\\path1;C:\\test\\path2;C:\\test\\path3"
+	testPath := testPathList(testDir("path1"), testDir("path2"), testDir("path3"))
 	os.Setenv("PATH", testPath)
 	
 	tests := []struct {
@@ -32,22 +42,22 @@ func TestPathManager_IsInPath(t *testing.T) {
 	}{
 		{
 			name: "path exists",
-			dir:  "C:\\test\\path1",
+			dir:  testDir("path1"),
 			want: true,
 		},
 		{
 			name: "path exists with different case",
-			dir:  "c:\\test\\path2",
+			dir:  strings.ToUpper(testDir("path2")),
 			want: true,
 		},
 		{
 			name: "path does not exist",
-			dir:  "C:\\test\\nonexistent",
+			dir:  testDir("nonexistent"),
 			want: false,
 		},
 		{
 			name: "path with trailing slash",
-			dir:  "C:\\test\\path3\\",
+			dir:  testDir("path3") + string(filepath.Separator),
 			want: true,
 		},
 	}
@@ -182,7 +192,7 @@ func TestPathManager_IsInPath_EmptyPath(t *testing.T) {
 	// Set empty PATH
 	os.Setenv("PATH", "")
 	
-	result := pm.IsInPath("C:\\test\\path")
+	result := pm.IsInPath(testDir("path"))
 	if result {
 		t.Error("IsInPath() should return false for empty PATH")
 	}
@@ -196,11 +206,11 @@ func TestPathManager_IsInPath_MultipleOccurrences(t *testing.T) {
 	defer os.Setenv("PATH", originalPath)
 	
 	// Set a test PATH with duplicates
-	testPath := "C:\\test\\path1;C:\\test\\path2;C:\\test\\path1"
+	testPath := testPathList(testDir("path1"), testDir("path2"), testDir("path1"))
 	os.Setenv("PATH", testPath)
 	
 	// Should still find it
-	result := pm.IsInPath("C:\\test\\path1")
+	result := pm.IsInPath(testDir("path1"))
 	if !result {
 		t.Error("IsInPath() should return true even with duplicates")
 	}
@@ -0,0 +1,355 @@
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Managed block markers written to shell profiles
+const (
+	profileBlockStart = "# >>> unosdk >>>"
+	profileBlockEnd   = "# <<< unosdk <<<"
+)
+
+// shPathFunc is the helper the generated sh script uses to prepend PATH entries
+const shPathFunc = "_unosdk_path"
+
+// UnixEnv handles environment variable management on Linux and macOS.
+// Variables and PATH entries are written to ~/.unosdk/env (and env.fish),
+// which bash, zsh and fish source through a managed block in their profiles.
+type UnixEnv struct {
+	homeDir string
+}
+
+// NewUnixEnv creates a new Unix environment manager
+func NewUnixEnv() *UnixEnv {
+	homeDir, _ := os.UserHomeDir()
+	return &UnixEnv{homeDir: homeDir}
+}
+
+// unixEnvState is the content of the generated env scripts
+type unixEnvState struct {
+	names []string
+	vars  map[string]string
+	path  []string
+}
+
+// EnvFile returns the path of the generated sh script
+func (u *UnixEnv) EnvFile() string {
+	return filepath.Join(u.homeDir, ".unosdk", "env")
+}
+
+// fishEnvFile returns the path of the generated fish script
+func (u *UnixEnv) fishEnvFile() string {
+	return u.EnvFile() + ".fish"
+}
+
+// Get returns a variable from the generated env scripts.
+// PATH only holds the entries unosdk manages.
+func (u *UnixEnv) Get(scope Scope, name string) (string, error) {
+	if scope == SystemScope {
+		return "", errSystemScope()
+	}
+
+	state, err := u.load()
+	if err != nil {
+		return "", err
+	}
+
+	if isPathVariable(name) {
+		return strings.Join(state.path, string(os.PathListSeparator)), nil
+	}
+
+	value, ok := state.vars[name]
+	if !ok {
+		return "", fmt.Errorf("failed to get environment variable: %s is not set", name)
+	}
+	return value, nil
+}
+
+// Set sets a variable in the generated env scripts
+func (u *UnixEnv) Set(scope Scope, name, value string) error {
+	if scope == SystemScope {
+		return errSystemScope()
+	}
+
+	state, err := u.load()
+	if err != nil {
+		return err
+	}
+
+	if isPathVariable(name) {
+		state.path = splitPath(value)
+	} else {
+		if _, ok := state.vars[name]; !ok {
+			state.names = append(state.names, name)
+		}
+		state.vars[name] = value
+	}
+
+	return u.save(state)
+}
+
+// Delete removes a variable from the generated env scripts
+func (u *UnixEnv) Delete(scope Scope, name string) error {
+	if scope == SystemScope {
+		return errSystemScope()
+	}
+
+	state, err := u.load()
+	if err != nil {
+		return err
+	}
+
+	if isPathVariable(name) {
+		state.path = nil
+		return u.save(state)
+	}
+
+	if _, ok := state.vars[name]; !ok {
+		return fmt.Errorf("failed to delete environment variable: %s is not set", name)
+	}
+	delete(state.vars, name)
+	for i, n := range state.names {
+		if n == name {
+			state.names = append(state.names[:i], state.names[i+1:]...)
+			break
+		}
+	}
+
+	return u.save(state)
+}
+
+// PrependPath moves or adds a directory to the front of the managed PATH entries
+func (u *UnixEnv) PrependPath(scope Scope, dir string) error {
+	if scope == SystemScope {
+		return errSystemScope()
+	}
+
+	state, err := u.load()
+	if err != nil {
+		return err
+	}
+
+	state.path = append([]string{dir}, removePathEntry(state.path, dir)...)
+	return u.save(state)
+}
+
+// RemovePath removes a directory from the managed PATH entries
+func (u *UnixEnv) RemovePath(scope Scope, dir string) error {
+	if scope == SystemScope {
+		return errSystemScope()
+	}
+
+	state, err := u.load()
+	if err != nil {
+		return err
+	}
+
+	state.path = removePathEntry(state.path, dir)
+	return u.save(state)
+}
+
+// IsAdmin always reports false, unosdk only manages the user's environment on Unix
+func (u *UnixEnv) IsAdmin() bool {
+	return false
+}
+
+// errSystemScope is returned for operations on the system-wide environment
+func errSystemScope() error {
+	return fmt.Errorf("system environment variables are not managed on this platform")
+}
+
+// load parses the generated sh script
+func (u *UnixEnv) load() (*unixEnvState, error) {
+	state := &unixEnvState{vars: make(map[string]string)}
+
+	file, err := os.Open(u.EnvFile())
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer file.Close()
+
+	// PATH entries are written last to first, so each one is prepended
+	var path []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, shPathFunc+" "):
+			path = append([]string{shUnquote(strings.TrimPrefix(line, shPathFunc+" "))}, path...)
+		case strings.HasPrefix(line, "export "):
+			name, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+			if !ok {
+				continue
+			}
+			if _, exists := state.vars[name]; !exists {
+				state.names = append(state.names, name)
+			}
+			state.vars[name] = shUnquote(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	state.path = path
+	return state, nil
+}
+
+// save writes the sh and fish scripts and hooks them into the shell profiles
+func (u *UnixEnv) save(state *unixEnvState) error {
+	if err := os.MkdirAll(filepath.Dir(u.EnvFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var sh, fish strings.Builder
+	sh.WriteString("# Generated by unosdk, do not edit. Use unosdk install/switch instead.\n")
+	sh.WriteString(shPathFunc + "() { case \":$PATH:\" in *\":$1:\"*) ;; *) PATH=\"$1:$PATH\" ;; esac; }\n")
+	fish.WriteString("# Generated by unosdk, do not edit. Use unosdk install/switch instead.\n")
+
+	for _, name := range state.names {
+		value := state.vars[name]
+		fmt.Fprintf(&sh, "export %s=%s\n", name, shQuote(value))
+		fmt.Fprintf(&fish, "set -gx %s %s\n", name, fishQuote(value))
+	}
+
+	for i := len(state.path) - 1; i >= 0; i-- {
+		dir := state.path[i]
+		fmt.Fprintf(&sh, "%s %s\n", shPathFunc, shQuote(dir))
+		fmt.Fprintf(&fish, "contains -- %s $PATH; or set -gx PATH %s $PATH\n", fishQuote(dir), fishQuote(dir))
+	}
+
+	sh.WriteString("export PATH\nunset -f " + shPathFunc + "\n")
+
+	if err := os.WriteFile(u.EnvFile(), []byte(sh.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write env file: %w", err)
+	}
+	if err := os.WriteFile(u.fishEnvFile(), []byte(fish.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write env file: %w", err)
+	}
+
+	return u.ensureProfiles()
+}
+
+// ensureProfiles adds the managed block sourcing the env script to the
+// user's shell profiles. Existing profiles are updated; if there are none,
+// the profile of the login shell is created.
+func (u *UnixEnv) ensureProfiles() error {
+	block := fmt.Sprintf("%s\n[ -f \"$HOME/.unosdk/env\" ] && . \"$HOME/.unosdk/env\"\n%s\n", profileBlockStart, profileBlockEnd)
+
+	var profiles []string
+	for _, name := range []string{".bashrc", ".bash_profile", ".zshrc", ".profile"} {
+		profile := filepath.Join(u.homeDir, name)
+		if _, err := os.Stat(profile); err == nil {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if len(profiles) == 0 {
+		name := ".bashrc"
+		if shell == "zsh" {
+			name = ".zshrc"
+		}
+		profiles = append(profiles, filepath.Join(u.homeDir, name))
+	}
+
+	for _, profile := range profiles {
+		if err := appendProfileBlock(profile, block); err != nil {
+			return err
+		}
+	}
+
+	// fish reads every script in conf.d on startup
+	fishConfig := filepath.Join(u.homeDir, ".config", "fish")
+	if _, err := os.Stat(fishConfig); err == nil || shell == "fish" {
+		confDir := filepath.Join(fishConfig, "conf.d")
+		if err := os.MkdirAll(confDir, 0755); err != nil {
+			return fmt.Errorf("failed to create fish config directory: %w", err)
+		}
+		hook := "test -f \"$HOME/.unosdk/env.fish\"; and source \"$HOME/.unosdk/env.fish\"\n"
+		if err := os.WriteFile(filepath.Join(confDir, "unosdk.fish"), []byte(hook), 0644); err != nil {
+			return fmt.Errorf("failed to write fish config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// appendProfileBlock appends the managed block to a profile unless it is already there
+func appendProfileBlock(profile, block string) error {
+	content, err := os.ReadFile(profile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", profile, err)
+	}
+	if strings.Contains(string(content), profileBlockStart) {
+		return nil
+	}
+
+	file, err := os.OpenFile(profile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", profile, err)
+	}
+	defer file.Close()
+
+	prefix := "\n"
+	if len(content) == 0 {
+		prefix = ""
+	}
+	if _, err := file.WriteString(prefix + block); err != nil {
+		return fmt.Errorf("failed to update %s: %w", profile, err)
+	}
+	return nil
+}
+
+// isPathVariable reports whether name refers to PATH ("Path" on Windows)
+func isPathVariable(name string) bool {
+	return strings.EqualFold(name, "PATH")
+}
+
+// splitPath splits a PATH value, dropping empty entries
+func splitPath(value string) []string {
+	var dirs []string
+	for _, dir := range strings.Split(value, string(os.PathListSeparator)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// removePathEntry returns the entries without dir
+func removePathEntry(entries []string, dir string) []string {
+	var kept []string
+	for _, entry := range entries {
+		if filepath.Clean(entry) != filepath.Clean(dir) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// shQuote quotes a value for POSIX shells
+func shQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// shUnquote reverses shQuote
+func shUnquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return strings.ReplaceAll(value[1:len(value)-1], `'\''`, "'")
+	}
+	return value
+}
+
+// fishQuote quotes a value for fish
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,148 @@
+package system
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func newTestUnixEnv(t *testing.T) *UnixEnv {
+	t.Helper()
+	t.Setenv("SHELL", "/bin/bash")
+	return &UnixEnv{homeDir: t.TempDir()}
+}
+
+func TestUnixEnv_Variables(t *testing.T) {
+	env := newTestUnixEnv(t)
+
+	if err := env.Set(UserScope, "JAVA_HOME", "/opt/jdk's"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := env.Set(UserScope, "MAVEN_HOME", "/opt/maven"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// Values survive a reload from disk, including quotes
+	reloaded := &UnixEnv{homeDir: env.homeDir}
+	if got, _ := reloaded.Get(UserScope, "JAVA_HOME"); got != "/opt/jdk's" {
+		t.Errorf("Get() = %v, want %v", got, "/opt/jdk's")
+	}
+
+	if err := env.Delete(UserScope, "MAVEN_HOME"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := env.Get(UserScope, "MAVEN_HOME"); err == nil {
+		t.Error("Get() should fail for a deleted variable")
+	}
+	if err := env.Delete(UserScope, "MAVEN_HOME"); err == nil {
+		t.Error("Delete() should fail for an unset variable")
+	}
+
+	if err := env.Set(SystemScope, "JAVA_HOME", "/opt/jdk"); err == nil {
+		t.Error("Set() should fail for the system scope")
+	}
+}
+
+func TestUnixEnv_Path(t *testing.T) {
+	env := newTestUnixEnv(t)
+
+	for _, dir := range []string{"/sdk/a/bin", "/sdk/b/bin", "/sdk/a/bin"} {
+		if err := env.PrependPath(UserScope, dir); err != nil {
+			t.Fatalf("PrependPath() error = %v", err)
+		}
+	}
+
+	got, _ := env.Get(UserScope, "PATH")
+	want := strings.Join([]string{"/sdk/a/bin", "/sdk/b/bin"}, string(os.PathListSeparator))
+	if got != want {
+		t.Errorf("PATH = %v, want %v", got, want)
+	}
+
+	if err := env.RemovePath(UserScope, "/sdk/a/bin/"); err != nil {
+		t.Fatalf("RemovePath() error = %v", err)
+	}
+	state, _ := env.load()
+	if !reflect.DeepEqual(state.path, []string{"/sdk/b/bin"}) {
+		t.Errorf("PATH entries = %v, want [/sdk/b/bin]", state.path)
+	}
+}
+
+func TestUnixEnv_Profiles(t *testing.T) {
+	env := newTestUnixEnv(t)
+	zshrc := filepath.Join(env.homeDir, ".zshrc")
+	os.WriteFile(zshrc, []byte("alias ll='ls -l'\n"), 0644)
+	os.MkdirAll(filepath.Join(env.homeDir, ".config", "fish"), 0755)
+
+	env.PrependPath(UserScope, "/sdk/a/bin")
+	env.PrependPath(UserScope, "/sdk/b/bin")
+
+	content, _ := os.ReadFile(zshrc)
+	if strings.Count(string(content), profileBlockStart) != 1 {
+		t.Errorf(".zshrc should contain the managed block once:\n%s", content)
+	}
+	if !strings.HasPrefix(string(content), "alias ll='ls -l'\n") {
+		t.Errorf(".zshrc should keep its content:\n%s", content)
+	}
+
+	// .bashrc did not exist, so only the existing profile is touched
+	if _, err := os.Stat(filepath.Join(env.homeDir, ".bashrc")); err == nil {
+		t.Error(".bashrc should not be created when another profile exists")
+	}
+
+	if _, err := os.Stat(filepath.Join(env.homeDir, ".config", "fish", "conf.d", "unosdk.fish")); err != nil {
+		t.Errorf("fish hook not written: %v", err)
+	}
+	fish, _ := os.ReadFile(env.fishEnvFile())
+	if !strings.Contains(string(fish), "set -gx PATH '/sdk/a/bin' $PATH") {
+		t.Errorf("env.fish missing PATH entry:\n%s", fish)
+	}
+}
+
+func TestUnixEnv_DefaultProfile(t *testing.T) {
+	env := newTestUnixEnv(t)
+	t.Setenv("SHELL", "/usr/bin/zsh")
+
+	env.Set(UserScope, "JAVA_HOME", "/opt/jdk")
+
+	content, err := os.ReadFile(filepath.Join(env.homeDir, ".zshrc"))
+	if err != nil {
+		t.Fatalf(".zshrc not created: %v", err)
+	}
+	if !strings.HasPrefix(string(content), profileBlockStart) {
+		t.Errorf(".zshrc = %q, want the managed block", content)
+	}
+}
+
+func TestUnixEnv_SourceScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	env := newTestUnixEnv(t)
+	env.Set(UserScope, "JAVA_HOME", "/opt/jdk 21")
+	env.PrependPath(UserScope, "/sdk/b/bin")
+	env.PrependPath(UserScope, "/sdk/a/bin")
+
+	// Sourcing twice must not duplicate PATH entries
+	script := ". " + shQuote(env.EnvFile()) + "; . " + shQuote(env.EnvFile()) + `; echo "$JAVA_HOME"; echo "$PATH"`
+	cmd := exec.Command(sh, "-c", script)
+	cmd.Env = []string{"PATH=/usr/bin:/bin"}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("sourcing env failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	want := []string{"/opt/jdk 21", "/sdk/a/bin:/sdk/b/bin:/usr/bin:/bin"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("sourced env = %q, want %q", lines, want)
+	}
+}
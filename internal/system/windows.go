@@ -1,3 +1,5 @@
+//go:build windows
+
 package system
 
 import (
@@ -185,89 +187,14 @@ func (w *WindowsEnv) GetSystemEnvironmentVariable(name string) (string, error) {
 	return value, nil
 }
 
-// DetectSDKConflicts checks for SDK installations in System PATH that would take precedence
-func (w *WindowsEnv) DetectSDKConflicts(sdkType string) []string {
+// DetectSDKConflicts checks for SDK installations in System PATH that would take precedence.
+// managed reports whether a directory belongs to unosdk.
+func (w *WindowsEnv) DetectSDKConflicts(sdkType string, managed func(string) bool) []string {
 	systemPath, err := w.GetSystemEnvironmentVariable("Path")
 	if err != nil {
 		return nil
 	}
-
-	var conflicts []string
-	paths := strings.Split(systemPath, ";")
-	
-	for _, p := range paths {
-		pTrimmed := strings.TrimSpace(p)
-		if pTrimmed == "" {
-			continue
-		}
-		pLower := strings.ToLower(pTrimmed)
-		
-		switch sdkType {
-		case "java":
-			// Check for common Java installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if (strings.Contains(pLower, "java") || strings.Contains(pLower, "jdk") || strings.Contains(pLower, "jre")) && 
-			   (strings.Contains(pLower, "bin") || strings.Contains(pLower, "javapath") || 
-			    strings.Contains(pLower, "corretto") || strings.Contains(pLower, "openjdk")) {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "node":
-			// Check for common Node.js installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "nodejs") || 
-			   (strings.Contains(pLower, "node") && (strings.Contains(pLower, "program files") || strings.Contains(pLower, "programfiles"))) {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "python":
-			// Check for common Python installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "python") && 
-			   (strings.Contains(pLower, "program files") || strings.Contains(pLower, "programfiles") || 
-			    strings.Contains(pLower, "appdata") || strings.Contains(pLower, "scripts")) {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "maven":
-			// Check for common Maven installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "maven") && strings.Contains(pLower, "bin") {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "flutter":
-			// Check for common Flutter installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "flutter") && strings.Contains(pLower, "bin") {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "gradle":
-			// Check for common Gradle installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "gradle") && strings.Contains(pLower, "bin") {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		case "go":
-			// Check for common Go installation paths (excluding unosdk)
-			if strings.Contains(pLower, "unosdk") {
-				continue // Skip our own installations
-			}
-			if strings.Contains(pLower, "go") && strings.Contains(pLower, "bin") {
-				conflicts = append(conflicts, pTrimmed)
-			}
-		}
-	}
-	
-	return conflicts
+	return DetectSDKConflicts(systemPath, sdkType, managed)
 }
 
 // RemoveFromSystemPath removes directories from System PATH (requires admin privileges)
@@ -416,3 +343,58 @@ func (w *WindowsEnv) IsAdmin() bool {
 	k.Close()
 	return true
 }
+
+// Get returns a user or system environment variable from the registry
+func (w *WindowsEnv) Get(scope Scope, name string) (string, error) {
+	if scope == SystemScope {
+		return w.GetSystemEnvironmentVariable(name)
+	}
+	return w.GetUserEnvironmentVariable(name)
+}
+
+// Set sets a user or system environment variable in the registry
+func (w *WindowsEnv) Set(scope Scope, name, value string) error {
+	if scope == SystemScope {
+		return w.SetSystemEnvironmentVariable(name, value)
+	}
+	return w.SetUserEnvironmentVariable(name, value)
+}
+
+// Delete deletes a user or system environment variable from the registry
+func (w *WindowsEnv) Delete(scope Scope, name string) error {
+	if scope == SystemScope {
+		return w.DeleteSystemEnvironmentVariable(name)
+	}
+	return w.DeleteUserEnvironmentVariable(name)
+}
+
+// PrependPath adds a directory to the front of the user or system PATH
+func (w *WindowsEnv) PrependPath(scope Scope, dir string) error {
+	if scope == SystemScope {
+		return w.AddToSystemPath(dir)
+	}
+	return w.AddToPath(dir)
+}
+
+// RemovePath removes a directory from the user or system PATH
+func (w *WindowsEnv) RemovePath(scope Scope, dir string) error {
+	if scope == SystemScope {
+		return w.RemoveFromSystemPathSingle(dir)
+	}
+	return w.RemoveFromPath(dir)
+}
+
+// DeleteSystemEnvironmentVariable deletes a system-level environment variable (requires admin)
+func (w *WindowsEnv) DeleteSystemEnvironmentVariable(name string) error {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Control\Session Manager\Environment`, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open registry key (admin rights required): %w", err)
+	}
+	defer k.Close()
+
+	if err := k.DeleteValue(name); err != nil {
+		return fmt.Errorf("failed to delete system environment variable: %w", err)
+	}
+
+	return w.broadcastEnvironmentChange()
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// InstallPathPlaceholder is replaced with the SDK install path in EnvSpec values
+const InstallPathPlaceholder = "{install_path}"
+
+// EnvSpec declares the environment variables and PATH entries an SDK needs
+type EnvSpec struct {
+	// Vars maps variable names to values, which may contain {install_path}
+	Vars map[string]string `json:"vars,omitempty" yaml:"vars,omitempty"`
+	// Path lists directories, relative to the install path, to add to PATH
+	Path []string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// Expand resolves the spec against an install path, returning the variables
+// to set and the absolute directories to add to PATH
+func (e EnvSpec) Expand(installPath string) (map[string]string, []string) {
+	vars := make(map[string]string, len(e.Vars))
+	for name, value := range e.Vars {
+		vars[name] = strings.ReplaceAll(value, InstallPathPlaceholder, installPath)
+	}
+
+	dirs := make([]string, 0, len(e.Path))
+	for _, dir := range e.Path {
+		if dir == "" || dir == "." {
+			dirs = append(dirs, installPath)
+			continue
+		}
+		dirs = append(dirs, filepath.Join(installPath, filepath.FromSlash(dir)))
+	}
+
+	return vars, dirs
+}
+
+// DefaultEnvSpec returns the environment rules of a built-in SDK type on the
+// given operating system: the conventional home variables tools and IDEs look
+// for, and the PATH directories. Providers implementing EnvProvider replace them.
+func DefaultEnvSpec(sdkType SDKType, goos string) EnvSpec {
+	home := InstallPathPlaceholder
+
+	switch sdkType {
+	case JavaSDK:
+		return EnvSpec{
+			Vars: map[string]string{"JAVA_HOME": home},
+			Path: []string{"bin"},
+		}
+	case NodeSDK:
+		spec := EnvSpec{Vars: map[string]string{"NODE_HOME": home}}
+		// The Windows zip keeps node.exe in its root directory
+		if goos == "windows" {
+			spec.Path = []string{"."}
+		} else {
+			spec.Path = []string{"bin"}
+		}
+		return spec
+	case PythonSDK:
+		// pip installs console scripts into Scripts on Windows
+		if goos == "windows" {
+			return EnvSpec{Path: []string{".", "Scripts"}}
+		}
+		return EnvSpec{Path: []string{"bin"}}
+	case MavenSDK:
+		// M2_HOME is the Maven 2 name, still read by older tools
+		return EnvSpec{
+			Vars: map[string]string{"MAVEN_HOME": home, "M2_HOME": home},
+			Path: []string{"bin"},
+		}
+	case GradleSDK:
+		return EnvSpec{
+			Vars: map[string]string{"GRADLE_HOME": home},
+			Path: []string{"bin"},
+		}
+	case GoSDK:
+		return EnvSpec{
+			Vars: map[string]string{"GOROOT": home},
+			Path: []string{"bin"},
+		}
+	case FlutterSDK:
+		return EnvSpec{
+			Vars: map[string]string{"FLUTTER_ROOT": home},
+			Path: []string{"bin"},
+		}
+	case CppSDK, CSDK:
+		// The MinGW install path already points at the extracted mingw64
+		// directory, which ships both compilers
+		sep, exe := "/", ""
+		if goos == "windows" {
+			sep, exe = `\`, ".exe"
+		}
+		return EnvSpec{
+			Vars: map[string]string{
+				"CC":  home + sep + "bin" + sep + "gcc" + exe,
+				"CXX": home + sep + "bin" + sep + "g++" + exe,
+			},
+			Path: []string{"bin"},
+		}
+	}
+	return EnvSpec{}
+}
+
+// IsEmpty reports whether the spec declares neither variables nor PATH entries
+func (e EnvSpec) IsEmpty() bool {
+	return len(e.Vars) == 0 && len(e.Path) == 0
+}
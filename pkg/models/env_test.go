@@ -0,0 +1,133 @@
+package models
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEnvSpec_Expand(t *testing.T) {
+	installPath := filepath.Join("home", ".unosdk", "protoc", "protobuf", "29.3")
+	spec := EnvSpec{
+		Vars: map[string]string{
+			"PROTOC_HOME":    "{install_path}",
+			"PROTOC_INCLUDE": "{install_path}" + string(filepath.Separator) + "include",
+			"PROTOC_MODE":    "strict",
+		},
+		Path: []string{"bin", ".", "tools/bin"},
+	}
+
+	vars, dirs := spec.Expand(installPath)
+
+	wantVars := map[string]string{
+		"PROTOC_HOME":    installPath,
+		"PROTOC_INCLUDE": filepath.Join(installPath, "include"),
+		"PROTOC_MODE":    "strict",
+	}
+	if !reflect.DeepEqual(vars, wantVars) {
+		t.Errorf("Expand() vars = %v, want %v", vars, wantVars)
+	}
+
+	wantDirs := []string{
+		filepath.Join(installPath, "bin"),
+		installPath,
+		filepath.Join(installPath, "tools", "bin"),
+	}
+	if !reflect.DeepEqual(dirs, wantDirs) {
+		t.Errorf("Expand() dirs = %v, want %v", dirs, wantDirs)
+	}
+}
+
+func TestDefaultEnvSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		sdkType  SDKType
+		goos     string
+		wantVars map[string]string
+		wantPath []string
+	}{
+		{
+			name:     "java sets JAVA_HOME",
+			sdkType:  JavaSDK,
+			goos:     "linux",
+			wantVars: map[string]string{"JAVA_HOME": InstallPathPlaceholder},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:     "node on windows",
+			sdkType:  NodeSDK,
+			goos:     "windows",
+			wantVars: map[string]string{"NODE_HOME": InstallPathPlaceholder},
+			wantPath: []string{"."},
+		},
+		{
+			name:     "node on linux",
+			sdkType:  NodeSDK,
+			goos:     "linux",
+			wantVars: map[string]string{"NODE_HOME": InstallPathPlaceholder},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:     "maven sets both home variables",
+			sdkType:  MavenSDK,
+			goos:     "linux",
+			wantVars: map[string]string{"MAVEN_HOME": InstallPathPlaceholder, "M2_HOME": InstallPathPlaceholder},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:     "go",
+			sdkType:  GoSDK,
+			goos:     "darwin",
+			wantVars: map[string]string{"GOROOT": InstallPathPlaceholder},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:     "python on windows",
+			sdkType:  PythonSDK,
+			goos:     "windows",
+			wantPath: []string{".", "Scripts"},
+		},
+		{
+			name:    "mingw on windows",
+			sdkType: CSDK,
+			goos:    "windows",
+			wantVars: map[string]string{
+				"CC":  InstallPathPlaceholder + `\bin\gcc.exe`,
+				"CXX": InstallPathPlaceholder + `\bin\g++.exe`,
+			},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:    "mingw on linux",
+			sdkType: CppSDK,
+			goos:    "linux",
+			wantVars: map[string]string{
+				"CC":  InstallPathPlaceholder + "/bin/gcc",
+				"CXX": InstallPathPlaceholder + "/bin/g++",
+			},
+			wantPath: []string{"bin"},
+		},
+		{
+			name:    "unknown type",
+			sdkType: SDKType("protoc"),
+			goos:    "windows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := DefaultEnvSpec(tt.sdkType, tt.goos)
+			if len(spec.Vars) != 0 || len(tt.wantVars) != 0 {
+				if !reflect.DeepEqual(spec.Vars, tt.wantVars) {
+					t.Errorf("DefaultEnvSpec() vars = %v, want %v", spec.Vars, tt.wantVars)
+				}
+			}
+			if !reflect.DeepEqual(spec.Path, tt.wantPath) {
+				t.Errorf("DefaultEnvSpec() path = %v, want %v", spec.Path, tt.wantPath)
+			}
+			if spec.IsEmpty() != (tt.wantVars == nil && tt.wantPath == nil) {
+				t.Errorf("IsEmpty() = %v", spec.IsEmpty())
+			}
+		})
+	}
+}
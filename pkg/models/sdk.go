@@ -17,7 +17,20 @@ const (
 	CSDK       SDKType = "c"
 )
 
-// SDK represents an SDK installation
+// Install sources of an SDK
+const (
+	// SourceDownload is an SDK installed from a vendor download
+	SourceDownload = "download"
+	// SourceExisting is an SDK whose install directory already had content
+	// and was adopted as is
+	SourceExisting = "existing"
+)
+
+// SDK represents an SDK installation. Checksum is the verified digest of the
+// download, Source how the SDK was installed (SourceDownload or
+// SourceExisting), Arch the architecture it was installed for, Size the
+// size of its install directory in bytes and Env the env spec of its provider
+// at install time.
 type SDK struct {
 	ID          string    `json:"id" yaml:"id"`
 	Name        string    `json:"name" yaml:"name"`
@@ -27,6 +40,10 @@ type SDK struct {
 	InstallPath string    `json:"install_path" yaml:"install_path"`
 	DownloadURL string    `json:"download_url" yaml:"download_url"`
 	Checksum    string    `json:"checksum" yaml:"checksum"`
+	Source      string    `json:"source,omitempty" yaml:"source,omitempty"`
+	Arch        string    `json:"arch,omitempty" yaml:"arch,omitempty"`
+	Size        int64     `json:"size,omitempty" yaml:"size,omitempty"`
+	Env         *EnvSpec  `json:"env,omitempty" yaml:"env,omitempty"`
 	Installed   bool      `json:"installed" yaml:"installed"`
 	InstalledAt time.Time `json:"installed_at,omitempty" yaml:"installed_at,omitempty"`
 }
@@ -51,3 +51,78 @@ func (v *Version) Compare(other *Version) int {
 func (v *Version) String() string {
 	return v.Raw
 }
+
+// CompareVersions compares two version strings component by component and
+// returns a negative number, zero or a positive number when a is older than,
+// equal to or newer than b. Numeric components are compared numerically
+// ("21.0.10" is newer than "21.0.9") and Java update versions like "8u392"
+// are compared as "8.392".
+func CompareVersions(a, b string) int {
+	partsA, partsB := versionParts(a), versionParts(b)
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		partA, partB := "0", "0"
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+
+		numA, errA := strconv.Atoi(partA)
+		numB, errB := strconv.Atoi(partB)
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				return numA - numB
+			}
+		case errA == nil:
+			// A release sorts after a pre-release tag like "rc1"
+			return 1
+		case errB == nil:
+			return -1
+		default:
+			if c := strings.Compare(partA, partB); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+// MajorVersion returns the first component of a version ("21" for "21.0.10",
+// "8" for "8u392"), or an empty string if it is not numeric
+func MajorVersion(v string) string {
+	parts := versionParts(v)
+	if len(parts) == 0 {
+		return ""
+	}
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		return ""
+	}
+	return parts[0]
+}
+
+// ValidateVersionName checks that a resolved version can name an install
+// directory: it must not be empty, "." or contain "..", path separators,
+// drive or stream colons, or control characters
+func ValidateVersionName(v string) error {
+	if v == "" || v == "." || strings.Contains(v, "..") {
+		return fmt.Errorf("invalid version %q", v)
+	}
+	for _, r := range v {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' || r == 0x7f {
+			return fmt.Errorf("invalid version %q: contains %q", v, r)
+		}
+	}
+	return nil
+}
+
+// versionParts splits a version string into its components
+func versionParts(v string) []string {
+	v = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v)), "v")
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '+' || r == '_' || r == 'u'
+	})
+}
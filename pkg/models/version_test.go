@@ -0,0 +1,83 @@
+package models
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"21.0.10", "21.0.9", 1},
+		{"21.0.9", "21.0.10", -1},
+		{"21.0.10", "21.0.10", 0},
+		{"v22.1.0", "22.1.0", 0},
+		{"8u392", "8u372", 1},
+		{"21", "21.0.0", 0},
+		{"21", "21.0.1", -1},
+		{"9.4.1", "9.4", 1},
+		{"3.13.0", "3.13.0-rc1", 1},
+		{"3.13.0-rc2", "3.13.0-rc1", 1},
+		{"1.26.1", "1.9.0", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got := CompareVersions(tt.a, tt.b)
+			if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+				t.Errorf("CompareVersions(%v, %v) = %v, want sign of %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMajorVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"21.0.10", "21"},
+		{"8u392", "8"},
+		{"v22.21.1", "22"},
+		{"25", "25"},
+		{"latest", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := MajorVersion(tt.version); got != tt.want {
+				t.Errorf("MajorVersion(%v) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateVersionName(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr bool
+	}{
+		{"21.0.10", false},
+		{"8u392", false},
+		{"3.13.0-rc1", false},
+		{"22.1.0+build.5", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../../x", true},
+		{"21/../../x", true},
+		{`..\x`, true},
+		{"21\\x", true},
+		{"C:x", true},
+		{"21\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if err := ValidateVersionName(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVersionName(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+		})
+	}
+}
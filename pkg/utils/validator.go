@@ -3,6 +3,8 @@ package utils
 import (
 	"fmt"
 	"regexp"
+
+	"github.com/javaquery/unosdk/pkg/models"
 )
 
 var (
@@ -27,20 +29,19 @@ func ValidateVersion(version string) error {
 	return nil
 }
 
-// ValidateSDKType validates an SDK type
+// ValidateSDKType validates an SDK type against the SDK types built into
+// unosdk. Types added by declarative providers (sdks.yaml) are not known here.
+//
+// Deprecated: use the HasType method of the provider catalog, which also
+// knows the declarative providers.
 func ValidateSDKType(sdkType string) error {
-	validTypes := map[string]bool{
-		"java":   true,
-		"node":   true,
-		"python": true,
-		"go":     true,
-	}
-
-	if !validTypes[sdkType] {
-		return fmt.Errorf("invalid SDK type: %s", sdkType)
+	switch models.SDKType(sdkType) {
+	case models.JavaSDK, models.NodeSDK, models.PythonSDK, models.GoSDK, models.FlutterSDK,
+		models.MavenSDK, models.GradleSDK, models.CppSDK, models.CSDK:
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("invalid SDK type: %s", sdkType)
 }
 
 // ValidateProvider validates a provider name
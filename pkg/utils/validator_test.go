@@ -83,6 +83,31 @@ func TestValidator_ValidateSDKType(t *testing.T) {
 			sdkType: "go",
 			wantErr: false,
 		},
+		{
+			name:    "valid flutter",
+			sdkType: "flutter",
+			wantErr: false,
+		},
+		{
+			name:    "valid cpp",
+			sdkType: "cpp",
+			wantErr: false,
+		},
+		{
+			name:    "valid maven",
+			sdkType: "maven",
+			wantErr: false,
+		},
+		{
+			name:    "valid c",
+			sdkType: "c",
+			wantErr: false,
+		},
+		{
+			name:    "declarative type",
+			sdkType: "kotlin",
+			wantErr: true,
+		},
 		{
 			name:    "invalid type",
 			sdkType: "invalid",